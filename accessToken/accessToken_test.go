@@ -0,0 +1,84 @@
+package accessToken
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+var key = []byte("0123456789abcdef0123456789abcdef")
+
+func TestIssueVerify(t *testing.T) {
+	issuer := NewIssuer(key, 15*time.Minute)
+	token, expiresAt, err := issuer.Issue(Claims{UserID: 7, Role: "admin", FirstName: "Ada", SessionID: "s1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(expiresAt); d <= 14*time.Minute || d > 15*time.Minute {
+		t.Errorf("token expires in %v, want 15m", d)
+	}
+
+	claims, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Claims{UserID: 7, Role: "admin", FirstName: "Ada", SessionID: "s1", ExpiresAt: time.Unix(expiresAt.Unix(), 0)}
+	if claims != want {
+		t.Errorf("Verify = %+v, want %+v", claims, want)
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	issuer := NewIssuer(key, -time.Second)
+	token, _, err := issuer.Issue(Claims{UserID: 7, Role: "student", SessionID: "s1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := issuer.Verify(token); err != ErrExpired {
+		t.Errorf("Verify of an expired token = %v, want ErrExpired", err)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	issuer := NewIssuer(key, 15*time.Minute)
+	token, _, err := issuer.Issue(Claims{UserID: 7, Role: "student", SessionID: "s1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	encode := base64.RawURLEncoding.EncodeToString
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"two parts", parts[0] + "." + parts[1]},
+		{"four parts", token + ".x"},
+		{"other key", func() string {
+			other, _, _ := NewIssuer([]byte("another key of at least 32 bytes!"), time.Minute).Issue(Claims{UserID: 7})
+			return other
+		}()},
+		{"changed payload", parts[0] + "." + encode([]byte(`{"sub":"1","role":"admin","sid":"s1","exp":9999999999}`)) + "." + parts[2]},
+		{"changed signature", parts[0] + "." + parts[1] + "." + encode([]byte("signature"))},
+		{"alg none", encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."},
+		{"alg HS512", encode([]byte(`{"alg":"HS512","typ":"JWT"}`)) + "." + parts[1] + "." + parts[2]},
+	}
+	for _, tt := range tests {
+		if _, err := issuer.Verify(tt.token); err != ErrInvalid {
+			t.Errorf("%s: Verify = %v, want ErrInvalid", tt.name, err)
+		}
+	}
+}
+
+func TestVerifySignedGarbage(t *testing.T) {
+	// A payload that is signed with the key but is not valid claims
+	issuer := NewIssuer(key, 15*time.Minute)
+	for _, payload := range []string{`not json`, `{"sub":"seven","exp":9999999999}`} {
+		signed := header + "." + base64.RawURLEncoding.EncodeToString([]byte(payload))
+		if _, err := issuer.Verify(signed + "." + issuer.sign(signed)); err != ErrInvalid {
+			t.Errorf("Verify of payload %s = %v, want ErrInvalid", payload, err)
+		}
+	}
+}
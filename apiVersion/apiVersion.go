@@ -0,0 +1,85 @@
+package apiVersion
+
+import (
+	"net/http"
+	"regexp"
+	"server/config"
+	"server/restErrors"
+	"server/router"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Legacy names the unversioned paths, such as /data/food-menu/, which are
+// kept as aliases of v1 for the clients shipped before versioning.
+const Legacy = "legacy"
+
+// Prefix returns the path prefix of the routes of version, e.g. "/v1", or ""
+// for Legacy.
+func Prefix(version string) string {
+	if version == Legacy {
+		return ""
+	}
+	return "/" + version
+}
+
+var versionPrefix = regexp.MustCompile(`^/v[1-9][0-9]*(/|$)`)
+
+// Unversioned returns pattern without its version prefix, so
+// "/v1/data/sports/" and "/data/sports/" both become "/data/sports/".
+func Unversioned(pattern string) string {
+	if loc := versionPrefix.FindStringIndex(pattern); loc != nil {
+		return "/" + pattern[loc[1]:]
+	}
+	return pattern
+}
+
+// ByUnversionedPattern passes route middleware the pattern of each route
+// without its version prefix, so settings keyed by pattern apply to every
+// version of a route.
+func ByUnversionedPattern(middleware router.RouteMiddleware) router.RouteMiddleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		return middleware(Unversioned(pattern), next)
+	}
+}
+
+// Middleware returns the middleware of the routes of version. Once the
+// version is listed in deprecated it announces its retirement with the
+// Deprecation, Sunset and successor Link headers, and once the sunset has
+// passed it answers 410 Gone instead of serving the route.
+func Middleware(version string, deprecated map[string]config.Deprecation) router.Middleware {
+	deprecation, ok := deprecated[version]
+	if !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	since, _ := time.Parse("2006-01-02", deprecation.Since)
+	var sunset time.Time
+	if deprecation.Sunset != "" {
+		sunset, _ = time.Parse("2006-01-02", deprecation.Sunset)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			// RFC 9745 dates the deprecation in Unix seconds
+			header.Set("Deprecation", "@"+strconv.FormatInt(since.Unix(), 10))
+			if deprecation.Successor != "" {
+				path := Prefix(deprecation.Successor) + strings.TrimPrefix(r.URL.Path, Prefix(version))
+				header.Set("Link", "<"+path+`>; rel="successor-version"`)
+			}
+			if !sunset.IsZero() {
+				header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+				if !time.Now().Before(sunset) {
+					message := "API version " + version + " was retired on " + deprecation.Sunset
+					if deprecation.Successor != "" {
+						message += "; use " + deprecation.Successor
+					}
+					restErrors.Write(w, r, http.StatusGone, message)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auditService
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/authService"
+	"server/databaseControllers"
+	"server/databaseTypes"
+	"server/logging"
+)
+
+// The actions recorded in the audit log.
+const (
+	Create  = "create"
+	Update  = "update"
+	Delete  = "delete"
+	Restore = "restore"
+	// Purge is deleting an entity in the trash for good.
+	Purge       = "purge"
+	Import      = "import"
+	Backup      = "backup"
+	Login       = "login"
+	LoginFailed = "login_failed"
+	Logout      = "logout"
+	// RevokeSessions is ending sessions other than the current one.
+	RevokeSessions = "revoke_sessions"
+	// TokenReused is a refresh token presented again after it was used,
+	// which ends its session.
+	TokenReused = "token_reused"
+)
+
+// The kinds of entity the audit log records changes to.
+const (
+	FoodMenu      = "food_menu"
+	Event         = "event"
+	ScheduleImage = "schedule_image"
+	LostAndFound  = "lost_and_found"
+	StoreItem     = "store_item"
+	User          = "user"
+	Database      = "database"
+)
+
+// Change is one change to record.
+type Change struct {
+	Action     string
+	EntityType string
+	EntityID   interface{}
+	// Before and After are the entity before and after the change, nil if
+	// it did not exist. They are stored as JSON.
+	Before interface{}
+	After  interface{}
+	// UserID is who made the change, if not the user authenticated for the
+	// request.
+	UserID int
+}
+
+// Service records the changes made through the API.
+type Service struct {
+	entries  databaseControllers.AuditRepo
+	clientIP func(*http.Request) string
+}
+
+// New returns a Service writing to entries, taking the address of the
+// client from clientIP.
+func New(entries databaseControllers.AuditRepo, clientIP func(*http.Request) string) *Service {
+	return &Service{entries: entries, clientIP: clientIP}
+}
+
+// Record adds change, made by the request r, to the audit log. The change
+// has already been made, so a failure to record it is logged rather than
+// returned.
+func (s *Service) Record(r *http.Request, change Change) {
+	entry := databaseTypes.AuditEntry{
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		IP:         s.clientIP(r),
+		RequestID:  logging.RequestID(r.Context()),
+	}
+	if change.EntityID != nil {
+		entry.EntityID = fmt.Sprint(change.EntityID)
+	}
+	if change.UserID != 0 {
+		entry.UserID = &change.UserID
+	} else if user, ok := authService.UserFromContext(r.Context()); ok {
+		entry.UserID = &user.ID
+	}
+
+	var err error
+	if entry.Before, err = snapshot(change.Before); err == nil {
+		entry.After, err = snapshot(change.After)
+	}
+	if err == nil {
+		err = s.entries.Record(entry)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("recording audit entry", "error", err,
+			"action", entry.Action, "entity_type", entry.EntityType, "entity_id", entry.EntityID)
+	}
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
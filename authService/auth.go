@@ -1,44 +1,144 @@
 package authService
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
 	"net/http"
+	"server/accessToken"
+	"server/databaseControllers"
 	"server/databaseTypes"
+	"server/logging"
+	"server/rateLimit"
+	"server/restErrors"
 	"server/restTypes"
 	"strings"
+	"sync"
 	"time"
 )
 
-func IsAuth(w http.ResponseWriter, r *http.Request) bool {
-	// Check authentication
-	_, erro := IsAuthorized(w, r)
-	if erro.Code != 0 {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return false
-	}
-	return true
+// Service hands out the tokens of login sessions and checks the access
+// tokens presented with requests.
+type Service struct {
+	tokens          databaseControllers.TokenRepo
+	users           databaseControllers.UserRepo
+	access          *accessToken.Issuer
+	refreshLifetime time.Duration
+	maxSessions     map[string]int
+	guesses         *rateLimit.Limiter
+	clientIP        func(*http.Request) string
+
+	mu sync.Mutex
+	// revoked maps the IDs of ended sessions to when the last access token
+	// issued for them expires.
+	revoked map[string]time.Time
 }
 
-func IsAuthorized(w http.ResponseWriter, r *http.Request) (databaseTypes.User, restTypes.ErrorResponse) {
-	db, err := sql.Open("sqlite3", "./database.db")
-	if err != nil {
-		panic(err)
+// NewService returns a Service that signs access tokens with access and
+// stores refresh tokens, valid for refreshLifetime after each refresh, in
+// tokens. maxSessions limits the sessions of each user by role, as
+// described by config.Auth.MaxSessions. Every request with a token that
+// access did not sign takes a token from the bucket of its client IP in
+// guesses, and once that is empty such requests get 429s until it refills,
+// so tokens cannot be guessed. Valid, expired and revoked tokens are not
+// limited.
+func NewService(tokens databaseControllers.TokenRepo, users databaseControllers.UserRepo, access *accessToken.Issuer, refreshLifetime time.Duration, maxSessions map[string]int, guesses *rateLimit.Limiter, clientIP func(*http.Request) string) *Service {
+	return &Service{tokens: tokens, users: users, access: access, refreshLifetime: refreshLifetime, maxSessions: maxSessions, guesses: guesses, clientIP: clientIP,
+		revoked: map[string]time.Time{}}
+}
+
+// Authenticated lets only requests with a valid bearer token through to next,
+// answering 401 to the rest. Handlers behind it get the user from UserFromContext.
+func (s *Service) Authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, sessionID, forged, erro := s.authorize(r)
+		if erro.Code != 0 {
+			if forged {
+				if ok, wait := s.guesses.Allow(s.clientIP(r)); !ok {
+					rateLimit.TooManyRequests(w, r, wait)
+					return
+				}
+			}
+			writeAuthError(w, r, erro)
+			return
+		}
+		ctx := context.WithValue(r.Context(), userKey{}, user)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, sessionKey{}, sessionID)))
+	})
+}
+
+// Live lets through to next only requests whose session, authenticated by
+// Authenticated before it, still exists in the database, answering 401 to
+// the rest. Authenticated alone knows only the sessions this process ended,
+// so an access token of a session ended by another process, or before a
+// restart, passes it until the token expires.
+func (s *Service) Live(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := SessionFromContext(r.Context())
+		ok, err := s.tokens.HasSession(id)
+		if err != nil {
+			restErrors.Internal(w, r, "checking session", err)
+			return
+		}
+		if !ok {
+			// Spare the database the next requests with the session's tokens
+			s.revoke(id)
+			writeAuthError(w, r, restTypes.ErrorResponse{Message: "Session has ended", Code: 401})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type userKey struct{}
+
+type sessionKey struct{}
+
+// UserFromContext returns the user authenticated by Authenticated for the
+// request handled under ctx.
+func UserFromContext(ctx context.Context) (databaseTypes.User, bool) {
+	user, ok := ctx.Value(userKey{}).(databaseTypes.User)
+	return user, ok
+}
+
+// SessionFromContext returns the ID of the session whose access token
+// authenticated the request handled under ctx.
+func SessionFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
+
+func writeAuthError(w http.ResponseWriter, r *http.Request, erro restTypes.ErrorResponse) {
+	if erro.Code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
 	}
-	defer db.Close()
+	restErrors.WriteResponse(w, r, erro)
+}
+
+// IsAuthorized checks the signed access token in the Authorization header
+// and returns the user it was issued to. The database is not consulted, so
+// the user is as they were when the token was issued and carries only their
+// ID, type and first name.
+func (s *Service) IsAuthorized(w http.ResponseWriter, r *http.Request) (databaseTypes.User, restTypes.ErrorResponse) {
+	user, _, _, erro := s.authorize(r)
+	return user, erro
+}
+
+// authorize is IsAuthorized, also returning the session of the token and
+// whether the token was rejected for not being signed by us, as a guessed
+// one would be.
+func (s *Service) authorize(r *http.Request) (databaseTypes.User, string, bool, restTypes.ErrorResponse) {
 	// Get the Authorization header from the request
 	authHeader := r.Header.Get("Authorization")
 	// Check if the Authorization header is present and has the correct format
 	if authHeader == "" {
 		// If the Authorization header is missing, return error
-		return databaseTypes.User{}, restTypes.ErrorResponse{
+		return databaseTypes.User{}, "", false, restTypes.ErrorResponse{
 			Message: "Authorization header is missing",
 			Code:    401,
 		}
 	}
 	if !strings.HasPrefix(authHeader, "Bearer ") {
 		// If the Authorization header has an invalid format, return error
-		return databaseTypes.User{}, restTypes.ErrorResponse{
+		return databaseTypes.User{}, "", false, restTypes.ErrorResponse{
 			Message: "Authorization header has an invalid format",
 			Code:    401,
 		}
@@ -47,43 +147,27 @@ func IsAuthorized(w http.ResponseWriter, r *http.Request) (databaseTypes.User, r
 	// Extract the token from the Authorization header
 	token := strings.TrimPrefix(authHeader, "Bearer ")
 
-	// Query the database for the most recent token for the given token
-	var addedAt time.Time
-	var user databaseTypes.User
-	err = db.QueryRow("SELECT id, user_type, first_name,last_name, email, added_at FROM LoginTokens, Users WHERE (LoginTokens.user_id = Users.id AND token = ? )ORDER BY added_at DESC LIMIT 1", token).Scan(&user.ID, &user.UserType, &user.FirstName, &user.LastName, &user.Email, &addedAt)
-	fmt.Println(err)
+	// Check the signature and expiry of the token
+	claims, err := s.access.Verify(token)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			time.Sleep(2 * time.Second)
-			// If the token is not found in the database, return error
-			return databaseTypes.User{}, restTypes.ErrorResponse{
-				Message: "Token is not found in the database",
-				Code:    401,
-			}
-		}
-		// If there was an error querying the database, return error
-		return databaseTypes.User{}, restTypes.ErrorResponse{
-			Message: "Internal Server Error",
-			Code:    500,
+		return databaseTypes.User{}, "", err == accessToken.ErrInvalid, restTypes.ErrorResponse{
+			Message: "Token is invalid or expired",
+			Code:    401,
 		}
 	}
-
-	// Check if the token is older than one day
-	if time.Since(addedAt) > 24*time.Hour {
-		// If the token is older than one day, delete it from the database
-		_, err = db.Exec("DELETE FROM LoginTokens WHERE token = ?", token)
-		if err != nil {
-			// If there was an error deleting the token from the database, return error
-			return databaseTypes.User{}, restTypes.ErrorResponse{
-				Message: "Internal Server Error",
-				Code:    500,
-			}
+	userType, ok := userTypeOf(Role(claims.Role))
+	if !ok {
+		return databaseTypes.User{}, "", false, restTypes.ErrorResponse{
+			Message: "Token is invalid or expired",
+			Code:    401,
 		}
-		// Return false to indicate that the token was deleted
-		return databaseTypes.User{}, restTypes.ErrorResponse{
-			Message: "Token is too old",
+	}
+	if s.isRevoked(claims.SessionID) {
+		return databaseTypes.User{}, "", false, restTypes.ErrorResponse{
+			Message: "Session has ended",
 			Code:    401,
 		}
 	}
-	return user, restTypes.ErrorResponse{Code: 0}
+	logging.SetUserID(r.Context(), claims.UserID)
+	return databaseTypes.User{ID: claims.UserID, UserType: userType, FirstName: claims.FirstName}, claims.SessionID, false, restTypes.ErrorResponse{Code: 0}
 }
@@ -0,0 +1,124 @@
+package authService
+
+import (
+	"fmt"
+	"net/http"
+	"server/databaseTypes"
+	"server/restErrors"
+	"sort"
+	"strings"
+)
+
+// Role names a user type.
+type Role string
+
+const (
+	RoleStudent      Role = "student"
+	RoleParent       Role = "parent"
+	RoleTeacher      Role = "teacher"
+	RoleDiningStaff  Role = "dining_staff"
+	RoleCoach        Role = "coach"
+	RoleStoreManager Role = "store_manager"
+	RoleAdmin        Role = "admin"
+)
+
+var roles = map[int]Role{
+	databaseTypes.UserTypeStudent:      RoleStudent,
+	databaseTypes.UserTypeParent:       RoleParent,
+	databaseTypes.UserTypeTeacher:      RoleTeacher,
+	databaseTypes.UserTypeDiningStaff:  RoleDiningStaff,
+	databaseTypes.UserTypeCoach:        RoleCoach,
+	databaseTypes.UserTypeStoreManager: RoleStoreManager,
+	databaseTypes.UserTypeAdmin:        RoleAdmin,
+}
+
+// RoleOf returns the role of a user type, or "" for an unknown type, which
+// has no permissions.
+func RoleOf(userType int) Role {
+	return roles[userType]
+}
+
+// userTypeOf returns the user type of a role, and false for an unknown role.
+func userTypeOf(role Role) (int, bool) {
+	for userType, r := range roles {
+		if r == role {
+			return userType, true
+		}
+	}
+	return 0, false
+}
+
+// CheckSessionLimits reports the keys of auth.max_sessions that are neither
+// a role nor "default".
+func CheckSessionLimits(limits map[string]int) error {
+	var unknown []string
+	for key := range limits {
+		if _, ok := userTypeOf(Role(key)); !ok && key != "default" {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("auth.max_sessions: unknown roles %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Permission allows an action on some of the data.
+type Permission string
+
+const (
+	// FoodMenuWrite allows adding, changing, importing and deleting food menus.
+	FoodMenuWrite Permission = "food_menu:write"
+	// ScheduleWrite allows changing the daily schedule events and images.
+	ScheduleWrite Permission = "schedule:write"
+	// LostAndFoundCreate allows reporting lost and found items.
+	LostAndFoundCreate Permission = "lost_and_found:create"
+	// LostAndFoundManage allows changing and deleting any lost and found item.
+	LostAndFoundManage Permission = "lost_and_found:manage"
+	// StoreWrite allows adding, changing and deleting school store products.
+	StoreWrite Permission = "store:write"
+	// Admin allows backups, people imports, the audit log, the trash and
+	// ending the sessions of other users.
+	Admin Permission = "admin"
+)
+
+// grants lists the permissions of each role. Administrators have every
+// permission and are not listed.
+var grants = map[Role][]Permission{
+	RoleStudent:      {LostAndFoundCreate},
+	RoleParent:       {LostAndFoundCreate},
+	RoleTeacher:      {LostAndFoundCreate, LostAndFoundManage, ScheduleWrite},
+	RoleDiningStaff:  {LostAndFoundCreate, FoodMenuWrite},
+	RoleCoach:        {LostAndFoundCreate},
+	RoleStoreManager: {LostAndFoundCreate, StoreWrite},
+}
+
+// Can reports whether the user has the permission.
+func Can(user databaseTypes.User, permission Permission) bool {
+	role := RoleOf(user.UserType)
+	if role == RoleAdmin {
+		return true
+	}
+	for _, granted := range grants[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Require lets only users with the permission through to next, answering
+// 401 without a valid token and 403 to users lacking it.
+func (s *Service) Require(permission Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return s.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := UserFromContext(r.Context())
+			if !Can(user, permission) {
+				restErrors.Write(w, r, http.StatusForbidden, "This requires the "+string(permission)+" permission")
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
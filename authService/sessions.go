@@ -0,0 +1,179 @@
+package authService
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"server/accessToken"
+	"server/databaseControllers"
+	"server/databaseTypes"
+	"time"
+)
+
+// Tokens are what a client is given at login and on every refresh.
+type Tokens struct {
+	// AccessToken is sent as the bearer token until it expires.
+	AccessToken string
+	ExpiresAt   time.Time
+	// RefreshToken is exchanged, once, for new Tokens at /auth/refresh.
+	RefreshToken string
+}
+
+// StartSession issues the tokens of a new login session for the user, whose
+// DeviceName, UserAgent and IP are taken from session. If the user already
+// has as many sessions as their role may, the oldest are ended to make room.
+// Expired sessions are cleared out on the way.
+func (s *Service) StartSession(user databaseTypes.User, session databaseTypes.Session) (Tokens, error) {
+	if err := s.tokens.DeleteExpired(); err != nil {
+		return Tokens{}, err
+	}
+	if limit := s.sessionLimit(user); limit > 0 {
+		evicted, err := s.tokens.EvictSessions(user.ID, limit-1)
+		if err != nil {
+			return Tokens{}, err
+		}
+		s.revoke(evicted...)
+	}
+	session.ID = uuid.New().String()
+	session.UserID = user.ID
+	refreshToken, expiresAt, err := s.newRefreshToken()
+	if err != nil {
+		return Tokens{}, err
+	}
+	token := databaseTypes.LoginToken{Token: refreshToken, Family: session.ID, UserID: user.ID, ExpiresAt: expiresAt}
+	if err := s.tokens.CreateSession(session, token); err != nil {
+		return Tokens{}, err
+	}
+	return s.issue(user, token)
+}
+
+// sessionLimit returns how many sessions the user may have, 0 for any number.
+func (s *Service) sessionLimit(user databaseTypes.User) int {
+	if limit, ok := s.maxSessions[string(RoleOf(user.UserType))]; ok {
+		return limit
+	}
+	return s.maxSessions["default"]
+}
+
+// Refresh exchanges a refresh token, presented from ip, for new tokens of
+// the same session, carrying the user's current role. It returns
+// ErrNotFound for an unknown or expired token. A token presented a second
+// time has leaked, so the whole session is ended and ErrTokenReused returned
+// with the user it was issued to.
+func (s *Service) Refresh(refreshToken, ip string) (databaseTypes.User, Tokens, error) {
+	used, err := s.tokens.Use(refreshToken)
+	if err == databaseControllers.ErrTokenReused {
+		if err := s.EndSession(used.UserID, used.Family); err != nil && err != databaseControllers.ErrNotFound {
+			return databaseTypes.User{}, Tokens{}, err
+		}
+		return databaseTypes.User{ID: used.UserID}, Tokens{}, databaseControllers.ErrTokenReused
+	}
+	if err != nil {
+		return databaseTypes.User{}, Tokens{}, err
+	}
+	user, err := s.users.GetByID(used.UserID)
+	if err != nil {
+		return databaseTypes.User{}, Tokens{}, err
+	}
+
+	next, expiresAt, err := s.newRefreshToken()
+	if err != nil {
+		return databaseTypes.User{}, Tokens{}, err
+	}
+	token := databaseTypes.LoginToken{Token: next, Family: used.Family, UserID: user.ID, ExpiresAt: expiresAt}
+	if err := s.tokens.Insert(token, ip); err != nil {
+		return databaseTypes.User{}, Tokens{}, err
+	}
+	tokens, err := s.issue(*user, token)
+	return *user, tokens, err
+}
+
+// SessionOf returns the ID of the session of a refresh token, used or not,
+// or ErrNotFound for an unknown or expired token.
+func (s *Service) SessionOf(refreshToken string) (string, error) {
+	token, err := s.tokens.Find(refreshToken)
+	if err != nil {
+		return "", err
+	}
+	return token.Family, nil
+}
+
+// Sessions returns the user's sessions that can still be refreshed.
+func (s *Service) Sessions(userID int) ([]databaseTypes.Session, error) {
+	return s.tokens.ListSessions(userID)
+}
+
+// EndSession ends one of the user's sessions: its refresh token stops
+// working and so, at once, do its access tokens. It returns ErrNotFound if
+// the user has no such session.
+func (s *Service) EndSession(userID int, id string) error {
+	if err := s.tokens.DeleteSession(userID, id); err != nil {
+		return err
+	}
+	s.revoke(id)
+	return nil
+}
+
+// EndSessions ends every session of the user and returns how many there were.
+func (s *Service) EndSessions(userID int) (int, error) {
+	ids, err := s.tokens.DeleteSessions(userID)
+	if err != nil {
+		return 0, err
+	}
+	s.revoke(ids...)
+	return len(ids), nil
+}
+
+// revoke rejects the access tokens of the sessions until they would have
+// expired anyway. This is kept in memory, so another server process, or
+// this one after a restart, still accepts them until they expire on the
+// routes not checked by Live.
+func (s *Service) revoke(ids ...string) {
+	now := time.Now()
+	until := now.Add(s.access.Lifetime())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, expires := range s.revoked {
+		if now.After(expires) {
+			delete(s.revoked, id)
+		}
+	}
+	for _, id := range ids {
+		s.revoked[id] = until
+	}
+}
+
+func (s *Service) isRevoked(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.revoked[id]
+	return ok && time.Now().Before(until)
+}
+
+func (s *Service) newRefreshToken() (string, time.Time, error) {
+	token, err := randomToken()
+	return token, time.Now().Add(s.refreshLifetime), err
+}
+
+// issue signs an access token for the session of the refresh token.
+func (s *Service) issue(user databaseTypes.User, refresh databaseTypes.LoginToken) (Tokens, error) {
+	access, expiresAt, err := s.access.Issue(accessToken.Claims{
+		UserID:    user.ID,
+		Role:      string(RoleOf(user.UserType)),
+		FirstName: user.FirstName,
+		SessionID: refresh.Family,
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+	return Tokens{AccessToken: access, ExpiresAt: expiresAt, RefreshToken: refresh.Token}, nil
+}
+
+// randomToken returns 32 random bytes, URL safe base64 encoded.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
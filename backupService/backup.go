@@ -0,0 +1,96 @@
+package backupService
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"server/config"
+	"server/databaseControllers"
+	"sort"
+	"sync"
+	"time"
+)
+
+// filePrefix starts the name of every backup file this service writes, so
+// rotation never touches anything else in the backup directory.
+const filePrefix = "database-"
+
+// Service takes online backups of the database and keeps the newest few.
+type Service struct {
+	db  *sql.DB
+	cfg config.Backup
+	// mu keeps a scheduled and a requested backup from running at once.
+	mu sync.Mutex
+}
+
+// New returns a Service backing up db as configured by cfg.
+func New(db *sql.DB, cfg config.Backup) *Service {
+	return &Service{db: db, cfg: cfg}
+}
+
+// Run writes a new backup file into the backup directory, deletes the ones
+// beyond the retention count and returns the path of the new file.
+func (s *Service) Run() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.cfg.Dir, 0o750); err != nil {
+		return "", err
+	}
+	// Down to the nanosecond, so backups taken within a second of each other
+	// do not replace one another, in a fixed width that keeps the names in
+	// time order
+	path := filepath.Join(s.cfg.Dir, filePrefix+time.Now().UTC().Format("20060102-150405.000000000")+".db")
+	if err := databaseControllers.BackupTo(s.db, path); err != nil {
+		return "", fmt.Errorf("backing up to %s: %w", path, err)
+	}
+	if err := s.rotate(); err != nil {
+		return path, fmt.Errorf("rotating backups: %w", err)
+	}
+	return path, nil
+}
+
+// rotate deletes all but the newest Retain backup files.
+func (s *Service) rotate() error {
+	files, err := filepath.Glob(filepath.Join(s.cfg.Dir, filePrefix+"*.db"))
+	if err != nil {
+		return err
+	}
+	// The timestamp in the name sorts oldest first
+	sort.Strings(files)
+	for len(files) > s.cfg.Retain {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// Start takes a backup every configured interval until stop is closed. It
+// does nothing if the interval is zero.
+func (s *Service) Start(stop <-chan struct{}) {
+	interval := time.Duration(s.cfg.Interval)
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				path, err := s.Run()
+				if err != nil {
+					slog.Error("scheduled backup failed", "error", err)
+					continue
+				}
+				slog.Info("scheduled backup written", "path", path)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
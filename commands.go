@@ -0,0 +1,195 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"server/backupService"
+	"server/config"
+	"server/databaseControllers"
+	"server/importService"
+	"strconv"
+)
+
+// commands are the subcommands accepted as the first argument instead of serving.
+var commands = map[string]func(cfg *config.Config, db *sql.DB, args []string) error{
+	"migrate":       migrateCommand,
+	"backup":        backupCommand,
+	"restore":       restoreCommand,
+	"import-people": importPeopleCommand,
+	"import-menus":  importMenusCommand,
+}
+
+// migrateCommand implements "server migrate up|down [steps]|status".
+func migrateCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: server migrate up|down [steps]|status")
+	}
+	switch args[0] {
+	case "up":
+		applied, err := databaseControllers.MigrateUp(db)
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("steps must be a positive number, got %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := databaseControllers.MigrateDown(db, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		states, err := databaseControllers.MigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		for _, state := range states {
+			status := "pending"
+			if state.Applied {
+				status = "applied " + state.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", state.Version, state.Name, status)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate action %q, expected up, down or status", args[0])
+	}
+}
+
+// backupCommand implements "server backup [file]". Without a file the backup
+// goes into the configured backup directory and old backups are rotated.
+func backupCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: server backup [file]")
+	}
+	if len(args) == 1 {
+		if err := databaseControllers.BackupTo(db, args[0]); err != nil {
+			return err
+		}
+		fmt.Println("backup written to", args[0])
+		return nil
+	}
+	path, err := backupService.New(db, cfg.Backup).Run()
+	if path != "" {
+		fmt.Println("backup written to", path)
+	}
+	return err
+}
+
+// restoreCommand implements "server restore <file>". The server must not be
+// running, since the database file is replaced underneath it.
+func restoreCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: server restore <backup file>")
+	}
+	// Release the database file before swapping it out
+	if err := db.Close(); err != nil {
+		return err
+	}
+	if err := databaseControllers.Restore(args[0], cfg.DatabasePath); err != nil {
+		return err
+	}
+	fmt.Printf("restored %s from %s; the previous database was kept as %s.before-restore\n", cfg.DatabasePath, args[0], cfg.DatabasePath)
+	return nil
+}
+
+// importPeopleCommand implements "server import-people [-dry-run] [dir]",
+// importing the configured People directory unless dir is given.
+func importPeopleCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("import-people", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "report what would change without writing anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dir := cfg.PeopleDir
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		dir = fs.Arg(0)
+	default:
+		return fmt.Errorf("usage: server import-people [-dry-run] [dir]")
+	}
+
+	report, err := importService.ImportPeople(databaseControllers.NewRepositories(db).Users, dir, *dryRun)
+	if err != nil {
+		return err
+	}
+	for _, person := range report.Added {
+		fmt.Printf("added\t%s\t%s %s\tinitial password: %s\n", person.Email, person.FirstName, person.LastName, person.InitialPassword)
+	}
+	for _, email := range report.Updated {
+		fmt.Printf("updated\t%s\n", email)
+	}
+	for _, email := range report.Departed {
+		fmt.Printf("departed\t%s\n", email)
+	}
+	for _, reason := range report.Skipped {
+		fmt.Printf("skipped\t%s\n", reason)
+	}
+	fmt.Printf("%d added, %d updated, %d unchanged, %d departed, %d skipped\n",
+		len(report.Added), len(report.Updated), report.Unchanged, len(report.Departed), len(report.Skipped))
+	if *dryRun {
+		fmt.Println("dry run: nothing was written")
+	}
+	return nil
+}
+
+// importMenusCommand implements "server import-menus [flags] <file>" for
+// files in the format of food.json; "-" reads standard input.
+func importMenusCommand(cfg *config.Config, db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("import-menus", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "report what would change without writing anything")
+	from := fs.String("from", "", "only import days on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "only import days on or before this date (YYYY-MM-DD)")
+	week := fs.String("week", "", "only import the 7 days starting on this date (YYYY-MM-DD)")
+	month := fs.String("month", "", "only import this month (YYYY-MM)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: server import-menus [-dry-run] [-week D | -month YYYY-MM | -from D -to D] <file>")
+	}
+	dates, err := importService.ParseDateRange(*from, *to, *week, *month)
+	if err != nil {
+		return err
+	}
+
+	in := os.Stdin
+	if fs.Arg(0) != "-" {
+		if in, err = os.Open(fs.Arg(0)); err != nil {
+			return err
+		}
+		defer in.Close()
+	}
+	report, err := importService.ImportMenus(databaseControllers.NewRepositories(db).FoodMenus, in, dates, *dryRun)
+	if report != nil {
+		for _, problem := range report.Errors {
+			fmt.Printf("error\t%s\t%s\n", problem.Field, problem.Message)
+		}
+		for _, day := range report.Days {
+			fmt.Printf("%s\t%s\t%s\n", day.Date, day.Result, day.Reason)
+		}
+		if len(report.Errors) == 0 {
+			fmt.Printf("%d inserted, %d updated, %d skipped\n", report.Inserted, report.Updated, report.Skipped)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Println("dry run: nothing was written")
+	}
+	return nil
+}
@@ -0,0 +1,621 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting the server reads at startup. Values are taken
+// from the defaults, then a JSON or YAML config file, then SERVER_*
+// environment variables, then command line flags, each overriding the one
+// before.
+type Config struct {
+	// Addr is the address the HTTP server listens on.
+	Addr string `json:"addr"`
+	// MetricsAddr is the address serving /metrics, kept apart from Addr as
+	// the metrics are not authenticated. Empty serves no metrics.
+	MetricsAddr string `json:"metrics_addr"`
+	// DatabasePath is the SQLite database file.
+	DatabasePath string `json:"database_path"`
+	// BuildDir holds the web front end served on "/".
+	BuildDir string `json:"build_dir"`
+	// PeopleDir holds students.json and teachers.json for "import-people".
+	PeopleDir string `json:"people_dir"`
+	// TokenLifetime is how long a login lasts: a refresh token is accepted
+	// this long after the last refresh.
+	TokenLifetime Duration  `json:"token_lifetime"`
+	Auth          Auth      `json:"auth"`
+	Server        Server    `json:"server"`
+	CORS          CORS      `json:"cors"`
+	Backup        Backup    `json:"backup"`
+	Trash         Trash     `json:"trash"`
+	Cache         Cache     `json:"cache"`
+	RateLimit     RateLimit `json:"rate_limit"`
+	TLS           TLS       `json:"tls"`
+	API           API       `json:"api"`
+}
+
+// Auth configures the access tokens handed out at login and refresh.
+type Auth struct {
+	// AccessTokenLifetime is how long an access token is accepted. Access
+	// tokens are checked without the database and cannot be taken back, so
+	// keep it short.
+	AccessTokenLifetime Duration `json:"access_token_lifetime"`
+	// SigningKey signs the access tokens and must be at least 32 bytes. If
+	// empty a random key is made at startup, so access tokens stop working
+	// on restart and clients must refresh them.
+	SigningKey string `json:"signing_key"`
+	// MaxSessions maps role names, such as "student", to how many sessions
+	// a user of the role may have at once; logging in once more ends their
+	// oldest session. "default" applies to the roles not listed and 0 is no
+	// limit. Entries in the config file are added to the defaults.
+	MaxSessions map[string]int `json:"max_sessions"`
+}
+
+// Server configures the timeouts of the HTTP server.
+type Server struct {
+	ReadHeaderTimeout Duration `json:"read_header_timeout"`
+	// ReadTimeout covers the whole request body, so it must allow for image uploads.
+	ReadTimeout  Duration `json:"read_timeout"`
+	WriteTimeout Duration `json:"write_timeout"`
+	IdleTimeout  Duration `json:"idle_timeout"`
+	// ShutdownTimeout is how long in-flight requests may take to finish after SIGINT or SIGTERM.
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+}
+
+// CORS configures which browser origins may call the API.
+type CORS struct {
+	// AllowedOrigins may call every route, with credentials. "*" allows any
+	// origin, but then without credentials.
+	AllowedOrigins []string `json:"allowed_origins"`
+	// PublicRoutes are route patterns any origin may read with GET and HEAD.
+	PublicRoutes []string `json:"public_routes"`
+	// MaxAge is how long browsers may cache the answer to a preflight request.
+	MaxAge Duration `json:"max_age"`
+}
+
+// Backup configures the scheduled online backups of the database.
+type Backup struct {
+	// Dir is where backup files are written.
+	Dir string `json:"dir"`
+	// Interval between scheduled backups; zero disables the schedule.
+	Interval Duration `json:"interval"`
+	// Retain is how many backup files to keep; older ones are deleted.
+	Retain int `json:"retain"`
+}
+
+// Trash configures how long deleted items can be restored.
+type Trash struct {
+	// Retention is how long deleted items stay in the trash before they are
+	// purged for good.
+	Retention Duration `json:"retention"`
+	// PurgeInterval is how often the trash is purged; zero never purges it.
+	PurgeInterval Duration `json:"purge_interval"`
+}
+
+// Cache configures the Cache-Control header of successful GET responses.
+type Cache struct {
+	// Default is sent by routes without a policy of their own.
+	Default string `json:"default"`
+	// Routes maps route patterns, written as in controllers/routes.go, to
+	// their Cache-Control header. Entries in the config file are added to
+	// the defaults, replacing those for the same pattern.
+	Routes map[string]string `json:"routes"`
+}
+
+// RateLimit configures the limits that answer 429 to clients sending too
+// many requests.
+type RateLimit struct {
+	// LoginIP limits login attempts and requests with unknown tokens per client IP.
+	LoginIP Limit `json:"login_ip"`
+	// LoginAccount limits login attempts per account email.
+	LoginAccount Limit `json:"login_account"`
+	// Refresh limits token refreshes per login session.
+	Refresh Limit `json:"refresh"`
+	// Writes limits POST, PUT and DELETE requests per user.
+	Writes Limit `json:"writes"`
+	// TrustProxy takes the client IP from X-Forwarded-For, which is only
+	// safe when the server is reachable through a reverse proxy alone.
+	TrustProxy bool `json:"trust_proxy"`
+}
+
+// Limit allows Requests per Per, in bursts of up to Burst (Requests if
+// zero). A limit of zero requests is off.
+type Limit struct {
+	Requests int      `json:"requests"`
+	Per      Duration `json:"per"`
+	Burst    int      `json:"burst,omitempty"`
+}
+
+// TLS configures HTTPS. The server speaks plain HTTP unless a certificate
+// and key are given.
+type TLS struct {
+	// CertFile and KeyFile are PEM files, the certificate chain first.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// ReloadInterval is how often the files are checked for a renewed
+	// certificate; zero only reads them at startup.
+	ReloadInterval Duration `json:"reload_interval"`
+	// MinVersion is the oldest TLS version accepted, "1.2" or "1.3".
+	MinVersion string `json:"min_version"`
+	// HSTSMaxAge is sent in the Strict-Transport-Security header; zero sends none.
+	HSTSMaxAge Duration `json:"hsts_max_age"`
+	// RedirectAddr, if set, is a plain HTTP address redirecting to HTTPS, e.g. ":80".
+	RedirectAddr string `json:"redirect_addr"`
+}
+
+// Enabled reports whether the server serves HTTPS.
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+// API configures the versions of the API.
+type API struct {
+	// Deprecated maps versions being retired, such as "v1" or "legacy" for
+	// the unversioned paths, to when.
+	Deprecated map[string]Deprecation `json:"deprecated"`
+}
+
+// Deprecation announces that an API version is being retired. Dates are
+// written YYYY-MM-DD.
+type Deprecation struct {
+	// Since is when the version was deprecated.
+	Since string `json:"since"`
+	// Sunset is when it stops being served, if decided.
+	Sunset string `json:"sunset,omitempty"`
+	// Successor is the version clients should move to, e.g. "v2".
+	Successor string `json:"successor,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "24h" or "90m" in the config file.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"24h\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) String() string { return time.Duration(d).String() }
+
+func (d *Duration) Set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// stringList is a comma separated flag value.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = splitList(s)
+	return nil
+}
+
+// Default returns the settings used when nothing overrides them.
+func Default() *Config {
+	return &Config{
+		Addr:          ":8082",
+		MetricsAddr:   "127.0.0.1:8083",
+		DatabasePath:  "database.db",
+		BuildDir:      "build",
+		PeopleDir:     "People",
+		TokenLifetime: Duration(30 * 24 * time.Hour),
+		Auth: Auth{
+			AccessTokenLifetime: Duration(15 * time.Minute),
+			MaxSessions:         map[string]int{"default": 5},
+		},
+		Server: Server{
+			ReadHeaderTimeout: Duration(10 * time.Second),
+			ReadTimeout:       Duration(2 * time.Minute),
+			WriteTimeout:      Duration(2 * time.Minute),
+			IdleTimeout:       Duration(2 * time.Minute),
+			ShutdownTimeout:   Duration(30 * time.Second),
+		},
+		CORS: CORS{
+			// The front end is served from this server and needs no CORS;
+			// add the origins of the web admin and the app's web build
+			AllowedOrigins: []string{"http://localhost:3000"},
+			PublicRoutes: []string{
+				"/data/food-menu/",
+				"/data/food-menu/all",
+				"/data/food-menu/{date}",
+				"/data/daily-schedule/",
+				"/data/daily-schedule/events",
+				"/data/daily-schedule/image",
+				"/data/lost-and-found/",
+				"/data/lost-and-found/image/{id}",
+				"/data/sports/",
+				"/data/games/",
+				"/data/school-store/",
+				"/data/school-store/image/{item_id}",
+			},
+			MaxAge: Duration(10 * time.Minute),
+		},
+		Backup: Backup{
+			Dir:      "backups",
+			Interval: Duration(24 * time.Hour),
+			Retain:   7,
+		},
+		Trash: Trash{
+			Retention:     Duration(30 * 24 * time.Hour),
+			PurgeInterval: Duration(time.Hour),
+		},
+		Cache: Cache{
+			// Revalidate by default; the ETag makes that cheap
+			Default: "no-cache",
+			Routes: map[string]string{
+				"/data/daily-schedule/image":         "public, max-age=300",
+				"/data/lost-and-found/image/{id}":    "public, max-age=300",
+				"/data/school-store/image/{item_id}": "public, max-age=300",
+				// The front end build puts a content hash in every file name
+				"/static/{path...}": "public, max-age=31536000, immutable",
+			},
+		},
+		RateLimit: RateLimit{
+			LoginIP:      Limit{Requests: 20, Per: Duration(time.Minute)},
+			LoginAccount: Limit{Requests: 5, Per: Duration(time.Minute)},
+			Refresh:      Limit{Requests: 10, Per: Duration(time.Minute)},
+			Writes:       Limit{Requests: 60, Per: Duration(time.Minute), Burst: 20},
+		},
+		TLS: TLS{
+			ReloadInterval: Duration(time.Minute),
+			MinVersion:     "1.2",
+			HSTSMaxAge:     Duration(365 * 24 * time.Hour),
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the config file, the
+// environment and the flags in args. It returns the arguments left after the
+// flags, which name the subcommand to run if any.
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("SERVER_CONFIG"), "path to a JSON, or YAML if named .yaml or .yml, config file (env SERVER_CONFIG)")
+	addr := fs.String("addr", "", "address to listen on (env SERVER_ADDR)")
+	databasePath := fs.String("db", "", "SQLite database file (env SERVER_DATABASE_PATH)")
+	buildDir := fs.String("build-dir", "", "directory of the web front end (env SERVER_BUILD_DIR)")
+	var tokenLifetime Duration
+	fs.Var(&tokenLifetime, "token-lifetime", "how long a login lasts without refreshing, e.g. 720h (env SERVER_TOKEN_LIFETIME)")
+	tlsCert := fs.String("tls-cert", "", "TLS certificate file; enables HTTPS (env SERVER_TLS_CERT_FILE)")
+	tlsKey := fs.String("tls-key", "", "TLS key file (env SERVER_TLS_KEY_FILE)")
+	var origins stringList
+	fs.Var(&origins, "cors-origins", "comma separated origins allowed by CORS (env SERVER_CORS_ORIGINS)")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configPath != "" {
+		if err := cfg.loadFile(*configPath); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, nil, err
+	}
+
+	// Only flags given on the command line override the earlier sources
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "addr":
+			cfg.Addr = *addr
+		case "db":
+			cfg.DatabasePath = *databasePath
+		case "build-dir":
+			cfg.BuildDir = *buildDir
+		case "token-lifetime":
+			cfg.TokenLifetime = tokenLifetime
+		case "cors-origins":
+			cfg.CORS.AllowedOrigins = origins
+		case "tls-cert":
+			cfg.TLS.CertFile = *tlsCert
+		case "tls-key":
+			cfg.TLS.KeyFile = *tlsKey
+		}
+	})
+
+	// Without a subcommand the server is started
+	if err := cfg.Validate(len(fs.Args()) == 0); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// loadFile reads the config file at path. A YAML file is converted to JSON
+// first, so both take the same keys and values.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	if v, ok := os.LookupEnv("SERVER_ADDR"); ok {
+		c.Addr = v
+	}
+	if v, ok := os.LookupEnv("SERVER_METRICS_ADDR"); ok {
+		c.MetricsAddr = v
+	}
+	if v, ok := os.LookupEnv("SERVER_DATABASE_PATH"); ok {
+		c.DatabasePath = v
+	}
+	if v, ok := os.LookupEnv("SERVER_BUILD_DIR"); ok {
+		c.BuildDir = v
+	}
+	if v, ok := os.LookupEnv("SERVER_PEOPLE_DIR"); ok {
+		c.PeopleDir = v
+	}
+	if v, ok := os.LookupEnv("SERVER_TOKEN_LIFETIME"); ok {
+		if err := c.TokenLifetime.Set(v); err != nil {
+			return fmt.Errorf("SERVER_TOKEN_LIFETIME: %w", err)
+		}
+	}
+	if v, ok := os.LookupEnv("SERVER_AUTH_SIGNING_KEY"); ok {
+		c.Auth.SigningKey = v
+	}
+	if v, ok := os.LookupEnv("SERVER_CORS_ORIGINS"); ok {
+		c.CORS.AllowedOrigins = splitList(v)
+	}
+	if v, ok := os.LookupEnv("SERVER_TLS_CERT_FILE"); ok {
+		c.TLS.CertFile = v
+	}
+	if v, ok := os.LookupEnv("SERVER_TLS_KEY_FILE"); ok {
+		c.TLS.KeyFile = v
+	}
+	if v, ok := os.LookupEnv("SERVER_TLS_REDIRECT_ADDR"); ok {
+		c.TLS.RedirectAddr = v
+	}
+	if v, ok := os.LookupEnv("SERVER_BACKUP_DIR"); ok {
+		c.Backup.Dir = v
+	}
+	if v, ok := os.LookupEnv("SERVER_BACKUP_INTERVAL"); ok {
+		if err := c.Backup.Interval.Set(v); err != nil {
+			return fmt.Errorf("SERVER_BACKUP_INTERVAL: %w", err)
+		}
+	}
+	if v, ok := os.LookupEnv("SERVER_TRASH_RETENTION"); ok {
+		if err := c.Trash.Retention.Set(v); err != nil {
+			return fmt.Errorf("SERVER_TRASH_RETENTION: %w", err)
+		}
+	}
+	if v, ok := os.LookupEnv("SERVER_RATE_LIMIT_TRUST_PROXY"); ok {
+		trust, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("SERVER_RATE_LIMIT_TRUST_PROXY: %w", err)
+		}
+		c.RateLimit.TrustProxy = trust
+	}
+	if v, ok := os.LookupEnv("SERVER_BACKUP_RETAIN"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("SERVER_BACKUP_RETAIN: %w", err)
+		}
+		c.Backup.Retain = n
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once. The settings only the
+// server uses, such as build_dir, are checked only when serving, so the
+// subcommands run where there is no front end build.
+func (c *Config) Validate(serving bool) error {
+	var problems []string
+	if c.Addr == "" {
+		problems = append(problems, "addr must not be empty")
+	}
+	if c.MetricsAddr != "" && (c.MetricsAddr == c.Addr || c.MetricsAddr == c.TLS.RedirectAddr) {
+		problems = append(problems, "metrics_addr must differ from addr and tls.redirect_addr")
+	}
+	if c.DatabasePath == "" {
+		problems = append(problems, "database_path must not be empty")
+	}
+	if serving {
+		if c.BuildDir == "" {
+			problems = append(problems, "build_dir must not be empty")
+		} else if info, err := os.Stat(c.BuildDir); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("build_dir %q is not a directory", c.BuildDir))
+		}
+	}
+	if c.PeopleDir == "" {
+		problems = append(problems, "people_dir must not be empty")
+	}
+	if c.TokenLifetime <= 0 {
+		problems = append(problems, "token_lifetime must be positive")
+	}
+	if c.Auth.AccessTokenLifetime <= 0 {
+		problems = append(problems, "auth.access_token_lifetime must be positive")
+	}
+	if c.Auth.SigningKey != "" && len(c.Auth.SigningKey) < 32 {
+		problems = append(problems, "auth.signing_key must be at least 32 bytes")
+	}
+	for role, limit := range c.Auth.MaxSessions {
+		if limit < 0 {
+			problems = append(problems, fmt.Sprintf("auth.max_sessions.%s must not be negative", role))
+		}
+	}
+	for _, timeout := range []struct {
+		name  string
+		value Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+	} {
+		if timeout.value <= 0 {
+			problems = append(problems, timeout.name+" must be positive")
+		}
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if !validOrigin(origin) {
+			problems = append(problems, fmt.Sprintf("cors.allowed_origins entry %q must be \"*\" or an origin such as https://admin.example.com", origin))
+		}
+	}
+	for _, pattern := range c.CORS.PublicRoutes {
+		if !strings.HasPrefix(pattern, "/") {
+			problems = append(problems, fmt.Sprintf("cors.public_routes entry %q must be a route pattern starting with /", pattern))
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		problems = append(problems, "cors.max_age must not be negative")
+	}
+	if c.Backup.Dir == "" {
+		problems = append(problems, "backup.dir must not be empty")
+	}
+	if c.Backup.Interval < 0 {
+		problems = append(problems, "backup.interval must not be negative")
+	}
+	if c.Backup.Retain < 1 {
+		problems = append(problems, "backup.retain must be at least 1")
+	}
+	if c.Trash.Retention <= 0 {
+		problems = append(problems, "trash.retention must be positive")
+	}
+	if c.Trash.PurgeInterval < 0 {
+		problems = append(problems, "trash.purge_interval must not be negative")
+	}
+	for pattern := range c.Cache.Routes {
+		if !strings.HasPrefix(pattern, "/") {
+			problems = append(problems, fmt.Sprintf("cache.routes key %q must be a route pattern starting with /", pattern))
+		}
+	}
+	for _, limit := range []struct {
+		name  string
+		value Limit
+	}{
+		{"rate_limit.login_ip", c.RateLimit.LoginIP},
+		{"rate_limit.login_account", c.RateLimit.LoginAccount},
+		{"rate_limit.refresh", c.RateLimit.Refresh},
+		{"rate_limit.writes", c.RateLimit.Writes},
+	} {
+		if limit.value.Requests < 0 || limit.value.Burst < 0 {
+			problems = append(problems, limit.name+" must not be negative")
+		}
+		if limit.value.Requests > 0 && limit.value.Per <= 0 {
+			problems = append(problems, limit.name+".per must be positive")
+		}
+	}
+	if c.TLS.Enabled() {
+		for _, file := range []struct{ name, path string }{
+			{"tls.cert_file", c.TLS.CertFile},
+			{"tls.key_file", c.TLS.KeyFile},
+		} {
+			if file.path == "" {
+				problems = append(problems, file.name+" must be set to serve HTTPS")
+			} else if _, err := os.Stat(file.path); err != nil {
+				problems = append(problems, fmt.Sprintf("%s %q cannot be read", file.name, file.path))
+			}
+		}
+		if c.TLS.RedirectAddr != "" && c.TLS.RedirectAddr == c.Addr {
+			problems = append(problems, "tls.redirect_addr must differ from addr")
+		}
+	} else if c.TLS.RedirectAddr != "" {
+		problems = append(problems, "tls.redirect_addr needs tls.cert_file and tls.key_file")
+	}
+	if c.TLS.MinVersion != "1.2" && c.TLS.MinVersion != "1.3" {
+		problems = append(problems, "tls.min_version must be 1.2 or 1.3")
+	}
+	if c.TLS.ReloadInterval < 0 {
+		problems = append(problems, "tls.reload_interval must not be negative")
+	}
+	if c.TLS.HSTSMaxAge < 0 {
+		problems = append(problems, "tls.hsts_max_age must not be negative")
+	}
+	for version, deprecation := range c.API.Deprecated {
+		if !apiVersionName.MatchString(version) {
+			problems = append(problems, fmt.Sprintf("api.deprecated key %q must be legacy or a version such as v1", version))
+		}
+		for _, date := range []struct{ name, value string }{
+			{"since", deprecation.Since},
+			{"sunset", deprecation.Sunset},
+		} {
+			if date.value == "" && date.name == "sunset" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", date.value); err != nil {
+				problems = append(problems, fmt.Sprintf("api.deprecated.%s.%s must be a date in the form YYYY-MM-DD", version, date.name))
+			}
+		}
+		if deprecation.Successor != "" && (deprecation.Successor == version || !apiVersionName.MatchString(deprecation.Successor)) {
+			problems = append(problems, fmt.Sprintf("api.deprecated.%s.successor must be another version", version))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// apiVersionName matches the names of API versions.
+var apiVersionName = regexp.MustCompile(`^(legacy|v[1-9][0-9]*)$`)
+
+// validOrigin reports whether origin is "*" or a scheme and host, with an
+// optional port and at most one "*" standing for subdomains.
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	if strings.Count(origin, "*") > 1 {
+		return false
+	}
+	u, err := url.Parse(strings.Replace(origin, "*", "wildcard", 1))
+	return err == nil && u.Scheme != "" && u.Host != "" && u.Path == "" && u.RawQuery == "" && u.User == nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"server/auditService"
+	"server/authService"
+	"server/backupService"
+	"server/databaseControllers"
+)
+
+// Handler serves the administrator endpoints.
+type Handler struct {
+	backups   *backupService.Service
+	users     databaseControllers.UserRepo
+	peopleDir string
+	audit     *auditService.Service
+	entries   databaseControllers.AuditRepo
+	trash     databaseControllers.TrashRepo
+	auth      *authService.Service
+}
+
+// NewHandler returns a Handler that takes backups with backups, imports
+// the People directory peopleDir into users, records to and reads the
+// audit log from entries through audit, lists and restores trash, and ends
+// users' sessions through auth.
+func NewHandler(backups *backupService.Service, users databaseControllers.UserRepo, peopleDir string, audit *auditService.Service, entries databaseControllers.AuditRepo, trash databaseControllers.TrashRepo, auth *authService.Service) *Handler {
+	return &Handler{backups: backups, users: users, peopleDir: peopleDir, audit: audit, entries: entries, trash: trash, auth: auth}
+}
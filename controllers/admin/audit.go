@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/databaseControllers"
+	"server/listQuery"
+	"server/restErrors"
+	"server/restTypes"
+)
+
+// GetAuditLog List the audit log
+// @Summary List the audit log
+// @Description Returns a page of the changes made through the API, newest first: who made each, from which IP and in which request, with the entity before and after. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param limit query int false "Most items to return, 1 to 200" default(50)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: id, created_at" default(-created_at)
+// @Param user_id query int false "Only changes made by this user"
+// @Param action query string false "Only this action" Enums(create, update, delete, restore, purge, import, backup, login, login_failed, logout, revoke_sessions, token_reused)
+// @Param entity_type query string false "Only changes to this kind of entity" Enums(food_menu, event, schedule_image, lost_and_found, store_item, user, database)
+// @Param entity_id query string false "Only changes to the entity with this ID"
+// @Param from query string false "Only changes at or after this time (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Only changes before this time (YYYY-MM-DD or RFC 3339)"
+// @Success 200 {object} restTypes.AuditLogResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/audit [get]
+func (h *Handler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	q, errs := listQuery.Parse(r.URL.Query(), databaseControllers.AuditListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
+	}
+
+	entries, total, err := h.entries.List(q)
+	if err != nil {
+		restErrors.Internal(w, r, "listing audit log", err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.AuditLogResponse{Items: entries, Page: q.Page(r, total)})
+}
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"server/auditService"
+	"server/logging"
+	"server/restErrors"
+	"server/restTypes"
+)
+
+// PostBackup Take a database backup
+// @Summary Take a database backup
+// @Description Writes an online backup of the whole database into the server's backup directory and rotates old backups. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Success 201 {object} restTypes.BackupResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/backups [post]
+func (h *Handler) PostBackup(w http.ResponseWriter, r *http.Request) {
+	path, err := h.backups.Run()
+	if path == "" {
+		restErrors.Internal(w, r, "writing backup", err)
+		return
+	}
+
+	response := restTypes.BackupResponse{
+		Status:  "success",
+		Message: "Backup created",
+		File:    filepath.Base(path),
+	}
+	if err != nil {
+		// The backup itself was written, only removing old ones failed
+		logging.FromContext(r.Context()).Error("rotating backups", "error", err)
+		response.Message = "Backup created, but old backups could not be rotated"
+	}
+	if info, err := os.Stat(path); err == nil {
+		response.SizeBytes = info.Size()
+	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Backup, EntityType: auditService.Database, EntityID: response.File, After: response})
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/auditService"
+	"server/importService"
+	"server/restErrors"
+)
+
+// PostImportPeople Import the People directory
+// @Summary Import the People directory into the user accounts
+// @Description Upserts every student and teacher listed in the server's People directory into Users. New accounts get a random initial password that is returned only in this response. Accounts no longer listed are reported as departed, not deleted. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param dry_run query bool false "Report what would change without writing anything"
+// @Success 200 {object} restTypes.ImportPeopleReport
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/import-people [post]
+func (h *Handler) PostImportPeople(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	report, err := importService.ImportPeople(h.users, h.peopleDir, dryRun)
+	if err != nil {
+		restErrors.Internal(w, r, "importing people", err)
+		return
+	}
+	if !dryRun {
+		// Only the counts, never the initial passwords
+		h.audit.Record(r, auditService.Change{Action: auditService.Import, EntityType: auditService.User, After: map[string]int{
+			"added":     len(report.Added),
+			"updated":   len(report.Updated),
+			"departed":  len(report.Departed),
+			"skipped":   len(report.Skipped),
+			"unchanged": report.Unchanged,
+		}})
+	}
+
+	// The report carries initial passwords
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/auditService"
+	"server/databaseControllers"
+	"server/restErrors"
+	"server/restTypes"
+	"server/router"
+)
+
+// GetUserSessions List the sessions of a user
+// @Summary List the sessions of a user
+// @Description Lists the devices the user is logged in on, most recently used first, each with the first characters of its refresh token. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} restTypes.SessionsResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "No such user"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/sessions [get]
+func (h *Handler) GetUserSessions(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.userParam(w, r)
+	if !ok {
+		return
+	}
+	sessions, err := h.auth.Sessions(id)
+	if err != nil {
+		restErrors.Internal(w, r, "listing sessions", err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.SessionsResponse{Items: sessions})
+}
+
+// DeleteUserSessions End every session of a user
+// @Summary End every session of a user
+// @Description Logs the user out on every device, e.g. when a phone is lost: their refresh tokens and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default). Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "No such user"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/sessions [delete]
+func (h *Handler) DeleteUserSessions(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.userParam(w, r)
+	if !ok {
+		return
+	}
+	n, err := h.auth.EndSessions(id)
+	if err != nil {
+		restErrors.Internal(w, r, "ending sessions", err)
+		return
+	}
+	h.audit.Record(r, auditService.Change{Action: auditService.RevokeSessions, EntityType: auditService.User, EntityID: id,
+		After: map[string]interface{}{"sessions": n}})
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.DeleteResponse{Status: "success", Message: "Sessions ended"})
+}
+
+// userParam returns the ID of the existing user in the id path parameter,
+// or answers the request and returns false.
+func (h *Handler) userParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := router.IntParam(r, "id")
+	if err != nil {
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid user ID")
+		return 0, false
+	}
+	if _, err := h.users.GetByID(id); err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "User not found")
+		return 0, false
+	} else if err != nil {
+		restErrors.Internal(w, r, "looking up user", err)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/auditService"
+	"server/databaseControllers"
+	"server/listQuery"
+	"server/restErrors"
+	"server/restTypes"
+	"server/router"
+)
+
+// GetTrash List the trash
+// @Summary List the trash
+// @Description Returns a page of the deleted food menus, events, schedule images, lost and found items and store products that can still be restored, most recently deleted first. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param limit query int false "Most items to return, 1 to 200" default(50)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: deleted_at, entity_type, name" default(-deleted_at)
+// @Param entity_type query string false "Only this kind of entity" Enums(food_menu, event, schedule_image, lost_and_found, store_item)
+// @Param from query string false "Only items deleted at or after this time (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Only items deleted before this time (YYYY-MM-DD or RFC 3339)"
+// @Success 200 {object} restTypes.TrashResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/trash [get]
+func (h *Handler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	q, errs := listQuery.Parse(r.URL.Query(), databaseControllers.TrashListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
+	}
+
+	items, total, err := h.trash.List(q)
+	if err != nil {
+		restErrors.Internal(w, r, "listing trash", err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.TrashResponse{Items: items, Page: q.Page(r, total)})
+}
+
+// PostRestore Restore an item from the trash
+// @Summary Restore an item from the trash
+// @Description Takes a deleted item out of the trash, as listed by GET /admin/trash. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param entity_type path string true "Kind of the item" Enums(food_menu, event, schedule_image, lost_and_found, store_item)
+// @Param id path string true "entity_id of the item"
+// @Success 200 {object} restTypes.RestoreResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "Not in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/trash/{entity_type}/{id}/restore [post]
+func (h *Handler) PostRestore(w http.ResponseWriter, r *http.Request) {
+	entityType, id := router.Param(r, "entity_type"), router.Param(r, "id")
+	item, err := h.trash.Restore(entityType, id)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found in the trash")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "restoring from trash", err)
+		return
+	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Restore, EntityType: entityType, EntityID: id, After: item})
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.RestoreResponse{Status: "success", Message: "Item restored", Item: *item})
+}
+
+// DeleteTrashItem Purge an item from the trash
+// @Summary Purge an item from the trash
+// @Description Deletes an item in the trash for good, as listed by GET /admin/trash, so a new one may take its key. Administrators only.
+// @Tags Admin
+// @Security Bearer[admin]
+// @Produce json
+// @Param entity_type path string true "Kind of the item" Enums(food_menu, event, schedule_image, lost_and_found, store_item)
+// @Param id path string true "entity_id of the item"
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "Not in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /admin/trash/{entity_type}/{id} [delete]
+func (h *Handler) DeleteTrashItem(w http.ResponseWriter, r *http.Request) {
+	entityType, id := router.Param(r, "entity_type"), router.Param(r, "id")
+	item, err := h.trash.Delete(entityType, id)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found in the trash")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "purging from trash", err)
+		return
+	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Purge, EntityType: entityType, EntityID: id, Before: item})
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.DeleteResponse{Status: "success", Message: "Item purged"})
+}
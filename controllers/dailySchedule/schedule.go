@@ -1,59 +1,99 @@
 package dailySchedule
 
 import (
-	"database/sql"
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"server/auditService"
+	"server/databaseControllers"
+	"server/httpCache"
+	"server/restErrors"
 	"server/restTypes"
+	"server/validation"
 	"time"
 )
 
+// Handler serves the daily schedule events and images.
+type Handler struct {
+	events databaseControllers.EventRepo
+	images databaseControllers.ScheduleImageRepo
+	audit  *auditService.Service
+}
+
+// NewHandler returns a Handler backed by events and images, recording
+// changes to audit.
+func NewHandler(events databaseControllers.EventRepo, images databaseControllers.ScheduleImageRepo, audit *auditService.Service) *Handler {
+	return &Handler{events: events, images: images, audit: audit}
+}
+
+// snapshot returns the stored event for the audit log, or nil if there is none.
+func (h *Handler) snapshot(id, date string) interface{} {
+	event, err := h.events.Get(id, date)
+	if err != nil {
+		return nil
+	}
+	return event
+}
+
+// imageSnapshot describes the stored image of date for the audit log, or
+// returns nil if there is none. The image itself is left out.
+func (h *Handler) imageSnapshot(date string) interface{} {
+	image, err := h.images.GetImage(date)
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{"date": date, "bytes": len(image.Data), "updated_at": image.UpdatedAt}
+}
+
 // PostDailySchedule @Summary Upload the daily schedule image for the current date
 // @Description Uploads the daily schedule event
 // @Tags Event
 // @Accept json
 // @Produce json
-// @Security Bearer
+// @Security Bearer[schedule:write]
 // @Param schedule body restTypes.Event true "Daily Schedule data to update"
-// @Success 201 {object} restTypes.LoginResponse
-// @Failure 400 {string} Bad Request
-// @Failure 500 {string} Internal Server Error
+// @Success 201 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 409 {object} restTypes.ErrorResponse "An event with the ID already exists or is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/ [post]
-func PostDailySchedule(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) PostDailySchedule(w http.ResponseWriter, r *http.Request) {
 	// Parse the JSON data from the request body
 	var schedule restTypes.Event
 	err := json.NewDecoder(r.Body).Decode(&schedule)
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid event JSON")
 		return
 	}
 
 	// Validate the schedule data
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Fatal(err)
+	if errs := validation.Struct(&schedule); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer db.Close()
 
 	// Insert the schedule data into the database
-	query := "INSERT INTO events (id, title, description, start, end, status, color, location) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
-	_, err = db.Exec(query, schedule.ID, schedule.Title, schedule.Description, schedule.Start, schedule.End, schedule.Status, schedule.Color, schedule.Location)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	err = h.events.Create(schedule)
+	if err == databaseControllers.ErrConflict {
+		restErrors.Write(w, r, http.StatusConflict, "An event with that ID already exists")
+		return
+	} else if err == databaseControllers.ErrTrashed {
+		restErrors.Write(w, r, http.StatusConflict, "The event with that ID is in the trash; restore or purge it through /admin/trash first")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "creating event", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Create, EntityType: auditService.Event, EntityID: schedule.ID, After: schedule})
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 
 	// Write the response
-	response := restTypes.LoginResponse{
+	response := restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Daily schedule uploaded successfully",
 	}
@@ -65,63 +105,48 @@ func PostDailySchedule(w http.ResponseWriter, r *http.Request) {
 // @Tags Event
 // @Accept json
 // @Produce json
-// @Security Bearer
+// @Security Bearer[schedule:write]
 // @Param schedule body restTypes.Event  true "Updated Daily Schedule data"
-// @Success 200 {object} restTypes.LoginResponse
-// @Failure 400 {string} Bad Request
-// @Failure 404 {string} Not Found
-// @Failure 500 {string} Internal Server Error
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/ [put]
-func PutDailySchedule(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) PutDailySchedule(w http.ResponseWriter, r *http.Request) {
 	// Parse the JSON data from the request body
 	var schedule restTypes.Event
 	err := json.NewDecoder(r.Body).Decode(&schedule)
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid event JSON")
 		return
 	}
-
-	// Extract the id and date from the Start time in the JSON data
-	id := schedule.ID
-	date := schedule.Start.Format("2006-01-02") // Format the date as "YYYY-MM-DD"
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Fatal(err)
-		return
-	}
-	defer db.Close()
-
-	// Check if the event exists in the database for the given ID and date
-	query := "SELECT COUNT(*) FROM events WHERE id=? AND DATE(start)=?"
-	var count int
-	err = db.QueryRow(query, id, date).Scan(&count)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	if errs := validation.Struct(&schedule); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
 
-	if count == 0 {
+	// Update the event with the same ID on the date of its Start time
+	date := schedule.Start.Format("2006-01-02")
+	before := h.snapshot(schedule.ID, date)
+	err = h.events.Update(schedule)
+	if err == databaseControllers.ErrNotFound {
 		// Event not found for the given ID and date
-		http.Error(w, "Event not found", http.StatusNotFound)
+		restErrors.Write(w, r, http.StatusNotFound, "Event not found")
 		return
-	}
-
-	// Update the event data in the database
-	query = "UPDATE events SET title=?, description=?, start=?, end=?, status=?, color=?, location=? WHERE id=? AND DATE(start)=?"
-	_, err = db.Exec(query, schedule.Title, schedule.Description, schedule.Start, schedule.End, schedule.Status, schedule.Color, schedule.Location, id, date)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	} else if err != nil {
+		restErrors.Internal(w, r, "updating event", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Update, EntityType: auditService.Event, EntityID: schedule.ID, Before: before, After: h.snapshot(schedule.ID, date)})
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 
 	// Write the response
-	response := restTypes.LoginResponse{
+	response := restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Daily schedule updated successfully",
 	}
@@ -133,19 +158,26 @@ func PutDailySchedule(w http.ResponseWriter, r *http.Request) {
 // @Tags Event
 // @Accept json
 // @Produce json
-// @Security Bearer
+// @Security Bearer[schedule:write]
 // @Param schedule body restTypes.Event  true "Data to delete an event from the daily schedule"
-// @Success 200 {object} restTypes.LoginResponse
-// @Failure 400 {string} Bad Request
-// @Failure 404 {string} Not Found
-// @Failure 500 {string} Internal Server Error
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/ [delete]
-func DeleteDailySchedule(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteDailySchedule(w http.ResponseWriter, r *http.Request) {
 	// Parse the JSON data from the request body
 	var schedule restTypes.Event
 	err := json.NewDecoder(r.Body).Decode(&schedule)
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid event JSON")
+		return
+	}
+	// Only the ID and start identify the event to delete
+	if errs := validation.Only(&schedule, "id", "start"); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
 
@@ -153,43 +185,25 @@ func DeleteDailySchedule(w http.ResponseWriter, r *http.Request) {
 	id := schedule.ID
 	date := schedule.Start.Format("2006-01-02") // Format the date as "YYYY-MM-DD"
 
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Fatal(err)
-		return
-	}
-	defer db.Close()
-
-	// Check if the event exists in the database for the given ID and date
-	query := "SELECT COUNT(*) FROM events WHERE id=? AND DATE(start)=?"
-	var count int
-	err = db.QueryRow(query, id, date).Scan(&count)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	if count == 0 {
+	// Delete the event from the database
+	before := h.snapshot(id, date)
+	err = h.events.Delete(id, date)
+	if err == databaseControllers.ErrNotFound {
 		// Event not found for the given ID and date
-		http.Error(w, "Event not found", http.StatusNotFound)
+		restErrors.Write(w, r, http.StatusNotFound, "Event not found")
 		return
-	}
-
-	// Delete the event from the database
-	query = "DELETE FROM events WHERE id=? AND DATE(start)=?"
-	_, err = db.Exec(query, id, date)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	} else if err != nil {
+		restErrors.Internal(w, r, "deleting event", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Delete, EntityType: auditService.Event, EntityID: id, Before: before})
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 
 	// Write the response
-	response := restTypes.LoginResponse{
+	response := restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Daily schedule deleted successfully",
 	}
@@ -203,48 +217,25 @@ func DeleteDailySchedule(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param date query string true "Date of events to retrieve (in the format 'YYYY-MM-DD')"
 // @Success 200 {object} restTypes.GetEventsResponse
-// @Failure 400 {string} Bad Request
-// @Failure 500 {string} Internal Server Error
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/events [get]
-func GetEventsByDate(w http.ResponseWriter, r *http.Request) {
+// @Router /data/daily-schedule/ [get]
+func (h *Handler) GetEventsByDate(w http.ResponseWriter, r *http.Request) {
 	// Parse the date query parameter from the URL
 	dateStr := r.URL.Query().Get("date")
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
-		return
-	}
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Fatal(err)
+		restErrors.Write(w, r, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
 		return
 	}
-	defer db.Close()
 
 	// Query all events for the specified date
-	query := "SELECT id, title, description, start, end, status, color, location FROM events WHERE DATE(start)=?"
-	rows, err := db.Query(query, date.Format("2006-01-02"))
+	events, err := h.events.ListByDate(date.Format("2006-01-02"))
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Fatal(err)
+		restErrors.Internal(w, r, "listing events", err)
 		return
 	}
-	defer rows.Close()
-
-	// Collect the events into a slice
-	var events []restTypes.Event
-	for rows.Next() {
-		var event restTypes.Event
-		err := rows.Scan(&event.ID, &event.Title, &event.Description, &event.Start, &event.End, &event.Status, &event.Color, &event.Location)
-		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			log.Fatal(err)
-			return
-		}
-		events = append(events, event)
-	}
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -269,17 +260,11 @@ func GetEventsByDate(w http.ResponseWriter, r *http.Request) {
 // @Produce  image/*
 // @Param date query string false "The date for which to retrieve the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used."
 // @Success 200 {string} OK
-// @Failure 401 {string} Unauthorized
-// @Failure 404 {string} Not Found
-// @Failure 500 {string} Internal Server Error
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/image [get]
-func GetDailyImage(w http.ResponseWriter, r *http.Request) {
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
+func (h *Handler) GetDailyImage(w http.ResponseWriter, r *http.Request) {
 	// Get the date parameter from the request, or use the current date if it's not provided
 	dateParam := r.URL.Query().Get("date")
 	date := time.Now().Format("2006-01-02")
@@ -287,7 +272,7 @@ func GetDailyImage(w http.ResponseWriter, r *http.Request) {
 		// Attempt to parse the provided date
 		t, err := time.Parse("2006-01-02", dateParam)
 		if err != nil {
-			http.Error(w, "Invalid date format. Please use 'YYYY-MM-DD' format.", http.StatusBadRequest)
+			restErrors.Write(w, r, http.StatusBadRequest, "Invalid date format. Please use 'YYYY-MM-DD' format.")
 			return
 		}
 		// Use the parsed date
@@ -295,25 +280,29 @@ func GetDailyImage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Query the database for the daily schedule image for the specified date
-	query := "SELECT image_file FROM DailyScheduleImages WHERE date = ?"
-	row := db.QueryRow(query, date)
-
-	// Extract the image data from the row
-	var imageData []byte
-	err = row.Scan(&imageData)
-	if err == sql.ErrNoRows {
+	image, err := h.images.GetImage(date)
+	if err == databaseControllers.ErrNotFound {
 		// If no record is found in the database, serve the "404.jpg" image instead
-		imageData, err = ioutil.ReadFile("static/404.png")
+		image = &databaseControllers.Image{}
+		image.Data, err = ioutil.ReadFile("static/404.png")
 		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			restErrors.Internal(w, r, "reading placeholder image", err)
 			return
 		}
+	} else if err != nil {
+		restErrors.Internal(w, r, "loading schedule image", err)
+		return
 	}
 
 	// Set the response headers and write the image data to the response body
 	w.Header().Set("Content-Type", "image/*")
+	if dateParam != "" {
+		// Without a date the URL shows a different day's image tomorrow,
+		// which may well be older
+		httpCache.SetLastModified(w, image.UpdatedAt)
+	}
 	w.WriteHeader(http.StatusOK)
-	w.Write(imageData)
+	w.Write(image.Data)
 }
 
 // PostDailyImage @Summary Upload the daily schedule image for a specific date
@@ -321,26 +310,29 @@ func GetDailyImage(w http.ResponseWriter, r *http.Request) {
 // @Tags Event
 // @Accept  multipart/form-data
 // @Produce  json
-// @Security Bearer
+// @Security Bearer[schedule:write]
 // @Param image formData file true "The daily schedule image file"
 // @Param date formData string true "The date for which the image is uploaded (format: 2006-01-02)"
-// @Success 201 {object} restTypes.LoginResponse
-// @Failure 400 {string} Bad Request
-// @Failure 401 {string} Unauthorized
-// @Failure 500 {string} Internal Server Error
+// @Success 201 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 409 {object} restTypes.ErrorResponse "The image for the date is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/image [post]
-func PostDailyImage(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) PostDailyImage(w http.ResponseWriter, r *http.Request) {
 	// Parse the form data
 	err := r.ParseMultipartForm(32 << 20) // Limit: 32 MB
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse form data")
 		return
 	}
 
 	// Get the image file from the form data
 	file, _, err := r.FormFile("image")
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "image is required")
 		return
 	}
 	defer file.Close()
@@ -348,57 +340,39 @@ func PostDailyImage(w http.ResponseWriter, r *http.Request) {
 	// Read the image data from the file
 	imageData, err := ioutil.ReadAll(file)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "reading uploaded image", err)
 		return
 	}
 
 	// Get the date parameter from the form data
 	date := r.FormValue("date")
 	if date == "" {
-		http.Error(w, "Bad Request - 'date' parameter is required", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Bad Request - 'date' parameter is required")
 		return
 	}
 
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	// Check if there is an existing record for the provided date
-	query := "SELECT id FROM DailyScheduleImages WHERE date = ?"
-	row := db.QueryRow(query, date)
-
-	var existingID int
-	err = row.Scan(&existingID)
-	if err == nil {
-		// If an existing record is found, update the image data
-		updateQuery := "UPDATE DailyScheduleImages SET image_file = ? WHERE date = ?"
-		_, err = db.Exec(updateQuery, imageData, date)
-		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-	} else if err == sql.ErrNoRows {
-		// If no record is found for the provided date, insert a new one
-		insertQuery := "INSERT INTO DailyScheduleImages (date, image_file, image_url, file_name) VALUES (?, ?, ?, ?)"
-		_, err = db.Exec(insertQuery, date, imageData, "", "")
-		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// Error occurred while querying the database
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	// Replace the image for the provided date, or add one if there is none yet
+	before := h.imageSnapshot(date)
+	err = h.images.SaveImage(date, imageData)
+	if err == databaseControllers.ErrTrashed {
+		restErrors.Write(w, r, http.StatusConflict, "The image for that date is in the trash; restore or purge it through /admin/trash first")
 		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "saving schedule image", err)
+		return
+	}
+	action := auditService.Create
+	if before != nil {
+		action = auditService.Update
 	}
+	h.audit.Record(r, auditService.Change{Action: action, EntityType: auditService.ScheduleImage, EntityID: date, Before: before, After: h.imageSnapshot(date)})
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 
 	// Write the response
-	response := restTypes.LoginResponse{
+	response := restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Daily schedule image uploaded successfully",
 	}
@@ -409,41 +383,47 @@ func PostDailyImage(w http.ResponseWriter, r *http.Request) {
 // @Description Deletes the daily schedule image for a specific date from the database
 // @Tags Event
 // @Produce  json
-// @Security Bearer
+// @Security Bearer[schedule:write]
 // @Param date query string false "The date for which to delete the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used."
-// @Success 200 {object} restTypes.LoginResponse
-// @Failure 400 {string} Bad Request
-// @Failure 401 {string} Unauthorized
-// @Failure 500 {string} Internal Server Error
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "No image for the date"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/daily-schedule/image [delete]
-func DeleteDailyImage(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) DeleteDailyImage(w http.ResponseWriter, r *http.Request) {
 	// Get the date parameter from the request, or use the current date if it's not provided
 	dateParam := r.URL.Query().Get("date")
 	date := time.Now().Format("2006-01-02")
 	if dateParam != "" {
-		date = dateParam
-	}
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
+		t, err := time.Parse("2006-01-02", dateParam)
+		if err != nil {
+			restErrors.Write(w, r, http.StatusBadRequest, "Invalid date format. Please use 'YYYY-MM-DD' format.")
+			return
+		}
+		date = t.Format("2006-01-02")
 	}
-	defer db.Close()
 
 	// Delete the record from the database using the date
-	query := "DELETE FROM DailyScheduleImages WHERE date = ?"
-	_, err = db.Exec(query, date)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	before := h.imageSnapshot(date)
+	err := h.images.DeleteImage(date)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "No daily schedule image for that date")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "deleting schedule image", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Delete, EntityType: auditService.ScheduleImage, EntityID: date, Before: before})
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 
 	// Write the response
-	response := restTypes.LoginResponse{
+	response := restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Daily schedule image deleted successfully",
 	}
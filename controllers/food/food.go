@@ -1,120 +1,156 @@
 package food
 
 import (
-	"database/sql"
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
 	"net/http"
+	"server/auditService"
+	"server/databaseControllers"
 	"server/databaseTypes"
+	"server/httpCache"
+	"server/importService"
+	"server/listQuery"
+	"server/restErrors"
 	"server/restTypes"
-	"strings"
+	"server/router"
+	"server/validation"
 	"time"
 )
 
+// Handler serves the food menu endpoints.
+type Handler struct {
+	menus databaseControllers.FoodMenuRepo
+	audit *auditService.Service
+}
+
+// NewHandler returns a Handler backed by menus, recording changes to audit.
+func NewHandler(menus databaseControllers.FoodMenuRepo, audit *auditService.Service) *Handler {
+	return &Handler{menus: menus, audit: audit}
+}
+
+// snapshot returns the stored menu of date for the audit log, or nil if
+// there is none.
+func (h *Handler) snapshot(date string) interface{} {
+	menu, err := h.menus.GetByDate(date)
+	if err != nil {
+		return nil
+	}
+	return menu
+}
+
+// trashedMenu answers a write onto the date of a menu in the trash.
+const trashedMenu = "The food menu for that date is in the trash; restore or purge it through /admin/trash first"
+
+// updateMenu replaces the menu of the date in the path with menu, recording
+// the change. It answers 404 if there is none and 409 if the menu would move
+// onto the date of another one, in the trash or not.
+func (h *Handler) updateMenu(w http.ResponseWriter, r *http.Request, menu databaseTypes.FoodMenu) bool {
+	date := router.Param(r, "date")
+	before := h.snapshot(date)
+	err := h.menus.Update(date, menu)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
+		return false
+	} else if err == databaseControllers.ErrConflict {
+		restErrors.Write(w, r, http.StatusConflict, "A food menu for that date already exists")
+		return false
+	} else if err == databaseControllers.ErrTrashed {
+		restErrors.Write(w, r, http.StatusConflict, trashedMenu)
+		return false
+	} else if err != nil {
+		restErrors.Internal(w, r, "updating food menu", err)
+		return false
+	}
+	// The update may move the menu to another date
+	h.audit.Record(r, auditService.Change{Action: auditService.Update, EntityType: auditService.FoodMenu, EntityID: date, Before: before, After: h.snapshot(menu.Date)})
+	return true
+}
+
 // PostFoodMenuHandler @Summary Add a food menu
 // @Summary Add a food menu
 // @Description Add a new food menu to the database
 // @Tags FoodMenu
-// @Security Bearer
+// @Security Bearer[food_menu:write]
 // @ID addFoodMenu
 // @Accept json
 // @Produce json
 // @Param foodMenu body databaseTypes.FoodMenu true "Food menu to add"
-// @Success 200 {object} restTypes.LoginResponse
-// @Failure 400 {string} string "Bad Request"
-// @Failure 401 {string} string "Unauthorized"
-// @Failure 500 {string} string "Internal Server Error"
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 409 {object} restTypes.ErrorResponse "A menu for the date already exists or is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
 // @Router /data/food-menu/ [post]
-func PostFoodMenuHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// TODO: Verify authentication here if needed
-
+func (h *Handler) PostFoodMenuHandler(w http.ResponseWriter, r *http.Request) {
 	var foodMenu databaseTypes.FoodMenu
 	err := json.NewDecoder(r.Body).Decode(&foodMenu)
 	if err != nil {
-		http.Error(w, "Failed to parse request body", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse request body")
 		return
 	}
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	stmt, err := db.Prepare("INSERT INTO FoodMenu (date, breakfast, lunch, dinner) VALUES (?, ?, ?, ?)")
-	if err != nil {
-		log.Fatal(err)
+	if errs := validation.Struct(&foodMenu); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
 	}
-	defer stmt.Close()
 
-	_, err = stmt.Exec(foodMenu.Date, foodMenu.Breakfast, foodMenu.Lunch, foodMenu.Dinner)
-	if err != nil {
-		log.Fatal(err)
+	if err := h.menus.Create(foodMenu); err == databaseControllers.ErrConflict {
+		restErrors.Write(w, r, http.StatusConflict, "A food menu for that date already exists")
+		return
+	} else if err == databaseControllers.ErrTrashed {
+		restErrors.Write(w, r, http.StatusConflict, trashedMenu)
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "creating food menu", err)
+		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Create, EntityType: auditService.FoodMenu, EntityID: foodMenu.Date, After: h.snapshot(foodMenu.Date)})
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(restTypes.LoginResponse{
+	json.NewEncoder(w).Encode(restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Food menu added successfully",
 	})
 }
 
-// PutFoodMenuHandler handles a PUT request to /food-menu/{id}
+// PutFoodMenuHandler handles a PUT request to /data/food-menu/{date}
 // @Summary Update a food menu
-// @Description Update the food menu with the specified ID
+// @Description Update the food menu for the specified date
 // @Tags FoodMenu
-// @Security Bearer
+// @Security Bearer[food_menu:write]
 // @Accept json
 // @Produce json
-// @Param id path string true "ID of the food menu to update"
+// @Param date path string true "Date of the food menu to update (YYYY-MM-DD)"
 // @Param foodMenu body databaseTypes.FoodMenu true "New values for the food menu"
-// @Success 200 {object} restTypes.LoginResponse
+// @Success 200 {object} restTypes.StatusResponse
 // @Failure 400 {object} restTypes.ErrorResponse
 // @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
 // @Failure 404 {object} restTypes.ErrorResponse
-// @Router /data/food-menu/{id} [put]
-func PutFoodMenuHandler(w http.ResponseWriter, r *http.Request, id string) {
-	if r.Method != "PUT" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	//TODO: Verify authentication here if needed
-
+// @Failure 409 {object} restTypes.ErrorResponse "A menu for the new date already exists or is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Router /data/food-menu/{date} [put]
+func (h *Handler) PutFoodMenuHandler(w http.ResponseWriter, r *http.Request) {
 	var foodMenu databaseTypes.FoodMenu
 	err := json.NewDecoder(r.Body).Decode(&foodMenu)
 	if err != nil {
-		http.Error(w, "Failed to parse request body", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse request body")
 		return
 	}
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	stmt, err := db.Prepare("UPDATE FoodMenu SET date=?, breakfast=?, lunch=?, dinner=? WHERE date=?")
-	if err != nil {
-		log.Fatal(err)
+	if errs := validation.Struct(&foodMenu); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
 	}
-	defer stmt.Close()
 
-	_, err = stmt.Exec(foodMenu.Date, foodMenu.Breakfast, foodMenu.Lunch, foodMenu.Dinner, id)
-	if err != nil {
-		log.Fatal(err)
+	if !h.updateMenu(w, r, foodMenu) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(restTypes.LoginResponse{
+	json.NewEncoder(w).Encode(restTypes.StatusResponse{
 		Status:  "success",
 		Message: "Food menu updated successfully",
 	})
@@ -124,57 +160,35 @@ func PutFoodMenuHandler(w http.ResponseWriter, r *http.Request, id string) {
 // @Summary Delete a food menu
 // @Description Delete a food menu from the database for a given date
 // @Tags FoodMenu
-// @Security Bearer
+// @Security Bearer[food_menu:write]
 // @ID DeleteFoodMenu
 // @Param date path string true "The date of the food menu to delete"
 // @Success 200 {object} restTypes.DeleteResponse
-// @Failure 400 {string} string "Bad Request"
-// @Failure 404 {string} string "Not Found"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} restTypes.ErrorResponse "Bad Request"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "Not Found"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
 // @Router /data/food-menu/{date} [delete]
-func DeleteFoodMenu(w http.ResponseWriter, r *http.Request, date string) {
-	// Check if the request method is DELETE
-	if r.Method != http.MethodDelete {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Open the database connection
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	defer db.Close()
-
+func (h *Handler) DeleteFoodMenu(w http.ResponseWriter, r *http.Request) {
 	// Delete the food menu for the given date
-	stmt, err := db.Prepare("DELETE FROM FoodMenu WHERE date = ?")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+	date := router.Param(r, "date")
+	before := h.snapshot(date)
+	err := h.menus.Delete(date)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
 		return
-	}
-	res, err := stmt.Exec(date)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	// Check if any row was affected by the delete operation
-	rowsAffected, err := res.RowsAffected()
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	if rowsAffected == 0 {
-		w.WriteHeader(http.StatusNotFound)
+	} else if err != nil {
+		restErrors.Internal(w, r, "deleting food menu", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Delete, EntityType: auditService.FoodMenu, EntityID: date, Before: before})
 
 	// Return a success message in JSON format
 	response := restTypes.DeleteResponse{Status: "success", Message: "Food menu deleted successfully"}
 	jsonResponse, err := json.Marshal(response)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding response", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -189,47 +203,29 @@ func DeleteFoodMenu(w http.ResponseWriter, r *http.Request, date string) {
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} databaseTypes.FoodMenu
-// @Failure 401 {string} Unauthorized
-// @Failure 404 {string} Not Found
-// @Failure 500 {string} Internal Server Error
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/food-menu/ [get]
-func GetFoodMenu(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetFoodMenu(w http.ResponseWriter, r *http.Request) {
 
 	// Get the current date
 	date := time.Now()
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
+
 	// Query the database for the food menu for the current date
-	query := "SELECT breakfast, lunch, dinner FROM FoodMenu WHERE date = ?"
-	row := db.QueryRow(query, date.Format("2006-01-02"))
-	fmt.Println(date.Format("2006-01-02"))
-	// Extract the values from the row
-	var breakfast, lunch, dinner string
-	err = row.Scan(&breakfast, &lunch, &dinner)
-	if err == sql.ErrNoRows {
-		fmt.Println(err.Error())
-		http.NotFound(w, r)
+	foodMenu, err := h.menus.GetByDate(date.Format("2006-01-02"))
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
 		return
 	} else if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "loading food menu", err)
 		return
 	}
 
-	// Create a FoodMenu struct from the values
-	foodMenu := databaseTypes.FoodMenu{
-		Date:      date.Format("2006-01-02"),
-		Breakfast: breakfast,
-		Lunch:     lunch,
-		Dinner:    dinner,
-	}
-
 	// Convert the FoodMenu struct to a JSON object
 	jsonData, err := json.Marshal(foodMenu)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding food menu", err)
 		return
 	}
 
@@ -248,58 +244,40 @@ func GetFoodMenu(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param   date      path    string    true        "The date of the food menu (YYYY-MM-DD)"
 // @Success 200 {object} databaseTypes.FoodMenu
-// @Failure 400 {string} Bad Request
-// @Failure 401 {string} Unauthorized
-// @Failure 404 {string} Not Found
-// @Failure 500 {string} Internal Server Error
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/food-menu/{date} [get]
-func GetFoodMenuByDate(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetFoodMenuByDate(w http.ResponseWriter, r *http.Request) {
 	// Get the date parameter from the path
-	dateStr := strings.TrimPrefix(r.URL.Path, "/data/food-menu/")
+	dateStr := router.Param(r, "date")
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.WriteFields(w, r, http.StatusBadRequest, "Invalid date", []restTypes.FieldError{{Field: "date", Message: "must be a date in the form YYYY-MM-DD"}})
 		return
 	}
 
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
 	// Query the database for the food menu for the specified date
-	query := "SELECT breakfast, lunch, dinner FROM FoodMenu WHERE date = ?"
-	row := db.QueryRow(query, date.Format("2006-01-02"))
-
-	// Extract the values from the row
-	var breakfast, lunch, dinner string
-	err = row.Scan(&breakfast, &lunch, &dinner)
-	if err == sql.ErrNoRows {
-		http.NotFound(w, r)
+	foodMenu, err := h.menus.GetByDate(date.Format("2006-01-02"))
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
 		return
 	} else if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "loading food menu", err)
 		return
 	}
 
-	// Create a FoodMenu struct from the values
-	foodMenu := databaseTypes.FoodMenu{
-		Date:      date.Format("2006-01-02"),
-		Breakfast: breakfast,
-		Lunch:     lunch,
-		Dinner:    dinner,
-	}
-
 	// Convert the FoodMenu struct to a JSON object
 	jsonData, err := json.Marshal(foodMenu)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding food menu", err)
 		return
 	}
 
 	// Set the response headers
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	httpCache.SetLastModified(w, foodMenu.UpdatedAt)
 	w.WriteHeader(http.StatusOK)
 
 	// Write the response
@@ -307,62 +285,38 @@ func GetFoodMenuByDate(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetAllFoodMenus @Summary Get all the food menus from the database
-// @Description Retrieves all the breakfast, lunch, and dinner menus from the database
+// @Description Retrieves the breakfast, lunch, and dinner menus from the database, a page at a time
 // @Tags FoodMenu
 // @Accept json
 // @Produce json
+// @Param limit query int false "Most items to return, 1 to 200; all of them when absent"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: date" default(date)
+// @Param date_from query string false "Only menus on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Only menus on or before this date (YYYY-MM-DD)"
 // @Success 200 {object} restTypes.AllMenuResponse
-// @Failure 500 {string} Internal Server Error
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/food-menu/all [get]
-func GetAllFoodMenus(w http.ResponseWriter, r *http.Request) {
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	// Query the database to get all food menus
-	query := "SELECT date, breakfast, lunch, dinner FROM FoodMenu"
-	rows, err := db.Query(query)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+func (h *Handler) GetAllFoodMenus(w http.ResponseWriter, r *http.Request) {
+	q, errs := listQuery.ParseUnpaged(r.URL.Query(), databaseControllers.FoodMenuListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer rows.Close()
-
-	// Create a slice to store all food menus
-	var foodMenus restTypes.AllMenuResponse
-
-	// Iterate over the rows and extract the values
-	for rows.Next() {
-		var date, breakfast, lunch, dinner string
-		err := rows.Scan(&date, &breakfast, &lunch, &dinner)
-		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-
-		// Create a FoodMenu struct for each row
-		foodMenu := databaseTypes.FoodMenu{
-			Date:      date,
-			Breakfast: breakfast,
-			Lunch:     lunch,
-			Dinner:    dinner,
-		}
-		foodMenus.Items = append(foodMenus.Items, foodMenu)
-		// Append the food menu to the slice
-		//foodMenus = append(foodMenus, foodMenu)
-	}
 
-	if err := rows.Err(); err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	// Query the database for the page of food menus
+	items, total, err := h.menus.List(q)
+	if err != nil {
+		restErrors.Internal(w, r, "listing food menus", err)
 		return
 	}
+	foodMenus := restTypes.AllMenuResponse{Items: items, Page: q.Page(r, total)}
 
 	// Convert the foodMenus slice to a JSON object
 	jsonData, err := json.Marshal(foodMenus)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding food menus", err)
 		return
 	}
 
@@ -373,3 +327,57 @@ func GetAllFoodMenus(w http.ResponseWriter, r *http.Request) {
 	// Write the response
 	w.Write(jsonData)
 }
+
+// ImportFoodMenus @Summary Bulk import food menus
+// @Summary Bulk import food menus
+// @Description Upserts many days of menus by date in one transaction. The body has the format of GET /data/food-menu/all (and of the scraped food.json dumps): breakfast, lunch and dinner are JSON encoded arrays of {name, ingredients, group}. Dates may be YYYY-MM-DD or RFC 3339. If any day is invalid nothing is written and the error lists every problem.
+// @Tags FoodMenu
+// @Security Bearer[food_menu:write]
+// @Accept json
+// @Produce json
+// @Param menus body restTypes.AllMenuResponse true "Menus to import"
+// @Param week query string false "Only import the 7 days starting on this date (YYYY-MM-DD)"
+// @Param month query string false "Only import this month (YYYY-MM)"
+// @Param from query string false "Only import days on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only import days on or before this date (YYYY-MM-DD)"
+// @Param dry_run query bool false "Report what would change without writing anything"
+// @Success 200 {object} restTypes.MenuImportReport
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 409 {object} restTypes.ErrorResponse "A menu to import is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
+// @Router /data/food-menu/import [post]
+func (h *Handler) ImportFoodMenus(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dates, err := importService.ParseDateRange(query.Get("from"), query.Get("to"), query.Get("week"), query.Get("month"))
+	if err != nil {
+		restErrors.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 32<<20)
+	report, err := importService.ImportMenus(h.menus, r.Body, dates, query.Get("dry_run") == "true")
+	if errors.Is(err, importService.ErrInvalidMenus) {
+		if report == nil {
+			restErrors.Write(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		restErrors.WriteFields(w, r, http.StatusBadRequest, "The menus are invalid; nothing was imported", report.Errors)
+		return
+	} else if errors.Is(err, databaseControllers.ErrTrashed) {
+		restErrors.Write(w, r, http.StatusConflict, err.Error()+"; restore or purge it through /admin/trash first. Nothing was imported")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "importing food menus", err)
+		return
+	}
+	if !report.DryRun {
+		h.audit.Record(r, auditService.Change{Action: auditService.Import, EntityType: auditService.FoodMenu, After: report})
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
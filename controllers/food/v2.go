@@ -0,0 +1,234 @@
+package food
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/auditService"
+	"server/databaseControllers"
+	"server/databaseTypes"
+	"server/httpCache"
+	"server/listQuery"
+	"server/restErrors"
+	"server/restTypes"
+	"server/router"
+	"server/validation"
+	"strings"
+	"time"
+)
+
+// Version 2 of the API serves menus with every meal as a list of dishes
+// instead of the JSON encoded string stored in the database.
+
+// GetFoodMenuV2 @Summary Get the food menu for the current date
+// @Summary Get the food menu for the current date
+// @Description Retrieves the breakfast, lunch, and dinner dishes for the current date
+// @Tags FoodMenu v2
+// @Produce json
+// @Success 200 {object} restTypes.Menu
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /v2/data/food-menu/ [get]
+func (h *Handler) GetFoodMenuV2(w http.ResponseWriter, r *http.Request) {
+	foodMenu, err := h.menus.GetByDate(time.Now().Format("2006-01-02"))
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "loading food menu", err)
+		return
+	}
+	writeJSON(w, r, structuredMenu(*foodMenu))
+}
+
+// GetFoodMenuByDateV2 @Summary Get the food menu for a specific date
+// @Summary Get the food menu for a specific date
+// @Description Retrieves the breakfast, lunch, and dinner dishes for a specific date
+// @Tags FoodMenu v2
+// @Produce json
+// @Param date path string true "The date of the food menu (YYYY-MM-DD)"
+// @Success 200 {object} restTypes.Menu
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /v2/data/food-menu/{date} [get]
+func (h *Handler) GetFoodMenuByDateV2(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse("2006-01-02", router.Param(r, "date"))
+	if err != nil {
+		restErrors.WriteFields(w, r, http.StatusBadRequest, "Invalid date", []restTypes.FieldError{{Field: "date", Message: "must be a date in the form YYYY-MM-DD"}})
+		return
+	}
+
+	foodMenu, err := h.menus.GetByDate(date.Format("2006-01-02"))
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Food menu not found")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "loading food menu", err)
+		return
+	}
+	httpCache.SetLastModified(w, foodMenu.UpdatedAt)
+	writeJSON(w, r, structuredMenu(*foodMenu))
+}
+
+// GetAllFoodMenusV2 @Summary Get all the food menus
+// @Summary Get all the food menus
+// @Description Retrieves the breakfast, lunch, and dinner dishes of every day, a page at a time
+// @Tags FoodMenu v2
+// @Produce json
+// @Param limit query int false "Most items to return, 1 to 200" default(50)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: date" default(date)
+// @Param date_from query string false "Only menus on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Only menus on or before this date (YYYY-MM-DD)"
+// @Success 200 {object} restTypes.MenuList
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /v2/data/food-menu/all [get]
+func (h *Handler) GetAllFoodMenusV2(w http.ResponseWriter, r *http.Request) {
+	q, errs := listQuery.Parse(r.URL.Query(), databaseControllers.FoodMenuListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
+	}
+
+	items, total, err := h.menus.List(q)
+	if err != nil {
+		restErrors.Internal(w, r, "listing food menus", err)
+		return
+	}
+	menus := restTypes.MenuList{Items: make([]restTypes.Menu, len(items)), Page: q.Page(r, total)}
+	for i, item := range items {
+		menus.Items[i] = structuredMenu(item)
+	}
+	writeJSON(w, r, menus)
+}
+
+// PostFoodMenuV2 @Summary Add a food menu
+// @Summary Add a food menu
+// @Description Add the dishes of a new day to the database
+// @Tags FoodMenu v2
+// @Security Bearer[food_menu:write]
+// @Accept json
+// @Produce json
+// @Param foodMenu body restTypes.Menu true "Food menu to add"
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 409 {object} restTypes.ErrorResponse "A menu for the date already exists or is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /v2/data/food-menu/ [post]
+func (h *Handler) PostFoodMenuV2(w http.ResponseWriter, r *http.Request) {
+	foodMenu, ok := decodeMenu(w, r)
+	if !ok {
+		return
+	}
+	if err := h.menus.Create(foodMenu); err == databaseControllers.ErrConflict {
+		restErrors.Write(w, r, http.StatusConflict, "A food menu for that date already exists")
+		return
+	} else if err == databaseControllers.ErrTrashed {
+		restErrors.Write(w, r, http.StatusConflict, trashedMenu)
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "creating food menu", err)
+		return
+	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Create, EntityType: auditService.FoodMenu, EntityID: foodMenu.Date, After: h.snapshot(foodMenu.Date)})
+	writeJSON(w, r, restTypes.StatusResponse{Status: "success", Message: "Food menu added successfully"})
+}
+
+// PutFoodMenuV2 @Summary Update a food menu
+// @Summary Update a food menu
+// @Description Replace the dishes of the food menu for the specified date
+// @Tags FoodMenu v2
+// @Security Bearer[food_menu:write]
+// @Accept json
+// @Produce json
+// @Param date path string true "Date of the food menu to update (YYYY-MM-DD)"
+// @Param foodMenu body restTypes.Menu true "New dishes of the food menu"
+// @Success 200 {object} restTypes.StatusResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 409 {object} restTypes.ErrorResponse "A menu for the new date already exists or is in the trash"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /v2/data/food-menu/{date} [put]
+func (h *Handler) PutFoodMenuV2(w http.ResponseWriter, r *http.Request) {
+	foodMenu, ok := decodeMenu(w, r)
+	if !ok {
+		return
+	}
+	if !h.updateMenu(w, r, foodMenu) {
+		return
+	}
+	writeJSON(w, r, restTypes.StatusResponse{Status: "success", Message: "Food menu updated successfully"})
+}
+
+// decodeMenu reads and validates a version 2 menu from the request body and
+// returns it in the stored form, answering 400 if it is invalid.
+func decodeMenu(w http.ResponseWriter, r *http.Request) (databaseTypes.FoodMenu, bool) {
+	var menu restTypes.Menu
+	if err := json.NewDecoder(r.Body).Decode(&menu); err != nil {
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse request body")
+		return databaseTypes.FoodMenu{}, false
+	}
+	if errs := validation.Struct(&menu); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return databaseTypes.FoodMenu{}, false
+	}
+	return databaseTypes.FoodMenu{
+		ID:        menu.ID,
+		Date:      menu.Date,
+		Breakfast: encodeDishes(menu.Breakfast),
+		Lunch:     encodeDishes(menu.Lunch),
+		Dinner:    encodeDishes(menu.Dinner),
+	}, true
+}
+
+func structuredMenu(menu databaseTypes.FoodMenu) restTypes.Menu {
+	return restTypes.Menu{
+		ID:        menu.ID,
+		Date:      menu.Date,
+		Breakfast: decodeDishes(menu.Breakfast),
+		Lunch:     decodeDishes(menu.Lunch),
+		Dinner:    decodeDishes(menu.Dinner),
+	}
+}
+
+// decodeDishes reads a stored meal. Meals entered as plain text, before
+// they were stored as JSON, become a single dish named by the text.
+func decodeDishes(meal string) []databaseTypes.Dish {
+	dishes := []databaseTypes.Dish{}
+	if strings.TrimSpace(meal) == "" {
+		return dishes
+	}
+	if err := json.Unmarshal([]byte(meal), &dishes); err != nil {
+		return []databaseTypes.Dish{{Name: meal}}
+	}
+	if dishes == nil {
+		return []databaseTypes.Dish{}
+	}
+	return dishes
+}
+
+func encodeDishes(dishes []databaseTypes.Dish) string {
+	if dishes == nil {
+		dishes = []databaseTypes.Dish{}
+	}
+	meal, _ := json.Marshal(dishes)
+	return string(meal)
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		restErrors.Internal(w, r, "encoding response", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
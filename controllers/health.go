@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"server/databaseControllers"
+	"server/restTypes"
+	"time"
+)
+
+// Healthz reports that the process is up and serving.
+// @Summary Liveness probe
+// @Description Always succeeds while the process is serving requests.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} restTypes.HealthResponse
+// @Router /healthz [get]
+func (c *Controllers) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, restTypes.HealthResponse{Status: "ok"})
+}
+
+// Readyz reports whether the server can handle traffic: the database must
+// answer and every migration must have been applied.
+// @Summary Readiness probe
+// @Description Succeeds when the database is reachable and its schema is up to date.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} restTypes.HealthResponse
+// @Failure 503 {object} restTypes.HealthResponse
+// @Router /readyz [get]
+func (c *Controllers) Readyz(w http.ResponseWriter, r *http.Request) {
+	response := restTypes.HealthResponse{Status: "ok", Checks: map[string]string{}}
+	status := http.StatusOK
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	if err := c.db.PingContext(ctx); err != nil {
+		response.Checks["database"] = err.Error()
+		status = http.StatusServiceUnavailable
+	} else {
+		response.Checks["database"] = "ok"
+		current, err := databaseControllers.MigrationsCurrent(c.db)
+		switch {
+		case err != nil:
+			response.Checks["migrations"] = err.Error()
+			status = http.StatusServiceUnavailable
+		case !current:
+			response.Checks["migrations"] = "pending migrations"
+			status = http.StatusServiceUnavailable
+		default:
+			response.Checks["migrations"] = "ok"
+		}
+	}
+
+	if status != http.StatusOK {
+		response.Status = "unavailable"
+	}
+	writeHealth(w, status, response)
+}
+
+func writeHealth(w http.ResponseWriter, status int, response restTypes.HealthResponse) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -1,12 +1,17 @@
 package controllers
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"golang.org/x/crypto/bcrypt"
 	"net/http"
+	"server/accessToken"
+	"server/auditService"
 	"server/authService"
-	_ "server/authService"
+	"server/backupService"
+	"server/config"
+	"server/controllers/admin"
 	"server/controllers/dailySchedule"
 	"server/controllers/food"
 	"server/controllers/lostAndFound"
@@ -14,15 +19,80 @@ import (
 	"server/controllers/sports"
 	"server/databaseControllers"
 	"server/databaseTypes"
+	"server/logging"
+	"server/metrics"
+	"server/rateLimit"
+	"server/restErrors"
 	"server/restTypes"
+	"server/validation"
+	"strconv"
 	"strings"
-	_ "strings"
+	"time"
+	"unicode/utf8"
 )
 
-func writeJson(w http.ResponseWriter, resp interface{}) {
+// Controllers dispatches the HTTP routes to the per-domain handlers.
+type Controllers struct {
+	db           *sql.DB
+	auth         *authService.Service
+	users        databaseControllers.UserRepo
+	food         *food.Handler
+	schedule     *dailySchedule.Handler
+	lostAndFound *lostAndFound.Handler
+	schoolStore  *schoolStore.Handler
+	sports       *sports.Handler
+	admin        *admin.Handler
+	audit        *auditService.Service
+	api          config.API
+	clientIP     func(*http.Request) string
+	loginIP      *rateLimit.Limiter
+	loginAccount *rateLimit.Limiter
+	refreshes    *rateLimit.Limiter
+	writes       *rateLimit.Limiter
+	// dummyHash is compared with the password of logins to unknown emails
+	// so they take as long as those to known ones.
+	dummyHash []byte
+}
+
+// New wires every handler to the repositories in repos. Access tokens are
+// signed with signingKey.
+func New(repos *databaseControllers.Repositories, cfg *config.Config, backups *backupService.Service, signingKey []byte) *Controllers {
+	clientIP := rateLimit.ByIP(cfg.RateLimit.TrustProxy)
+	// Login attempts and unknown tokens share one bucket per IP
+	loginIP := rateLimit.New("login_ip", cfg.RateLimit.LoginIP)
+	access := accessToken.NewIssuer(signingKey, time.Duration(cfg.Auth.AccessTokenLifetime))
+	auth := authService.NewService(repos.Tokens, repos.Users, access, time.Duration(cfg.TokenLifetime), cfg.Auth.MaxSessions, loginIP, clientIP)
+	audit := auditService.New(repos.Audit, clientIP)
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("not anyone's password"), bcrypt.DefaultCost)
+	if err != nil {
+		// Only a cost out of range fails
+		panic(err)
+	}
+	return &Controllers{
+		db:           repos.DB,
+		auth:         auth,
+		users:        repos.Users,
+		food:         food.NewHandler(repos.FoodMenus, audit),
+		schedule:     dailySchedule.NewHandler(repos.Events, repos.ScheduleImages, audit),
+		lostAndFound: lostAndFound.NewHandler(repos.LostAndFound, audit),
+		schoolStore:  schoolStore.NewHandler(repos.Store, audit),
+		sports:       sports.NewHandler(repos.Sports),
+		admin:        admin.NewHandler(backups, repos.Users, cfg.PeopleDir, audit, repos.Audit, repos.Trash, auth),
+		audit:        audit,
+		api:          cfg.API,
+		clientIP:     clientIP,
+		loginIP:      loginIP,
+		loginAccount: rateLimit.New("login_account", cfg.RateLimit.LoginAccount),
+		refreshes:    rateLimit.New("refresh", cfg.RateLimit.Refresh),
+		writes:       rateLimit.New("writes", cfg.RateLimit.Writes),
+		dummyHash:    dummyHash,
+	}
+}
+
+func writeJson(w http.ResponseWriter, r *http.Request, resp interface{}) {
 	jsonResp, err := json.Marshal(resp)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding response", err)
 		return
 	}
 
@@ -32,59 +102,167 @@ func writeJson(w http.ResponseWriter, resp interface{}) {
 	w.Write(jsonResp)
 }
 
-// LoginHandler handles user authentication and generates an authentication token.
+// LoginHandler handles user authentication and starts a session.
 //
 // @Summary Authenticate user
-// @Description Login to the system and receive an authentication token.
+// @Description Login to the system and receive a short-lived JWT access token and a refresh token to renew it with.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param login body restTypes.LoginRequest true "User login information"
 // @Success 200 {object} restTypes.LoginResponse
+// @Failure 400 {object} restTypes.ErrorResponse
 // @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 429 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /auth/login [post]
-func LoginHandler(w http.ResponseWriter, r *http.Request) {
-	// Check request method
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
+func (c *Controllers) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req restTypes.LoginRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
+	}
+	// Limit the passwords tried per account, from however many addresses
+	if ok, wait := c.loginAccount.Allow(strings.ToLower(req.Username)); !ok {
+		rateLimit.TooManyRequests(w, r, wait)
 		return
 	}
 
 	// Validate credentials
-	user, e := databaseControllers.GetUserByEmail(req.Username)
-	if e.Code != 0 {
-		writeJson(w, e)
+	user, err := c.users.GetByEmail(req.Username)
+	if err == databaseControllers.ErrNotFound {
+		// Answer exactly as for a wrong password, taking as long, so emails
+		// cannot be probed
+		bcrypt.CompareHashAndPassword(c.dummyHash, []byte(req.Password))
+		metrics.LoginAttempts.Inc("failure")
+		c.recordFailedLogin(r, req.Username)
+		restErrors.Write(w, r, http.StatusUnauthorized, "Invalid username or password")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "looking up user", err)
 		return
 	}
-	s, _ := bcrypt.GenerateFromPassword([]byte(user.Password), 4)
-	fmt.Println(string(s))
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		http.Error(w, "Invalid username or password", http.StatusUnauthorized)
+		metrics.LoginAttempts.Inc("failure")
+		c.recordFailedLogin(r, req.Username)
+		restErrors.Write(w, r, http.StatusUnauthorized, "Invalid username or password")
 		return
 	}
 
-	// Generate JWT token
-	token, err := databaseControllers.GenerateToken(user.ID)
+	// Start a session with a JWT access token and a refresh token
+	logging.SetUserID(r.Context(), user.ID)
+	tokens, err := c.auth.StartSession(*user, databaseTypes.Session{
+		DeviceName: req.DeviceName,
+		UserAgent:  truncate(r.UserAgent(), 200),
+		IP:         c.clientIP(r),
+	})
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "issuing tokens", err)
 		return
 	}
 
-	// Build response
-	resp := restTypes.LoginResponse{
-		Status:  "success",
-		Message: "Login successful",
-		Token:   token,
+	metrics.LoginAttempts.Inc("success")
+	c.audit.Record(r, auditService.Change{Action: auditService.Login, EntityType: auditService.User, EntityID: user.ID, UserID: user.ID})
+	writeJson(w, r, tokenResponse("Login successful", *user, tokens))
+
+}
+
+// RefreshHandler exchanges a refresh token for new tokens.
+//
+// @Summary Refresh the access token
+// @Description Exchange a refresh token for a new access token and a new refresh token. Each refresh token works once: presenting one again ends its session, logging out whoever holds it. A session may be refreshed 10 times a minute by default.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param refresh body restTypes.RefreshRequest true "Refresh token"
+// @Success 200 {object} restTypes.LoginResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 429 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /auth/refresh [post]
+func (c *Controllers) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	var req restTypes.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
+	}
+
+	// Each session may refresh so often; only unknown tokens count against
+	// the client IP, as guesses
+	ip := c.clientIP(r)
+	session, err := c.auth.SessionOf(req.RefreshToken)
+	if err == databaseControllers.ErrNotFound {
+		c.rejectRefresh(w, r, ip)
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "looking up refresh token", err)
+		return
+	}
+	if ok, wait := c.refreshes.Allow(session); !ok {
+		rateLimit.TooManyRequests(w, r, wait)
+		return
+	}
+
+	user, tokens, err := c.auth.Refresh(req.RefreshToken, ip)
+	if err == databaseControllers.ErrTokenReused {
+		logging.FromContext(r.Context()).Warn("refresh token reused, session ended", "user_id", user.ID)
+		c.audit.Record(r, auditService.Change{Action: auditService.TokenReused, EntityType: auditService.User, EntityID: user.ID, UserID: user.ID})
+		restErrors.Write(w, r, http.StatusUnauthorized, "Refresh token was already used; log in again")
+		return
+	} else if err == databaseControllers.ErrNotFound {
+		// The token expired or its session ended since it was looked up
+		c.rejectRefresh(w, r, ip)
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "refreshing tokens", err)
+		return
+	}
+	logging.SetUserID(r.Context(), user.ID)
+	writeJson(w, r, tokenResponse("Tokens refreshed", user, tokens))
+}
+
+// rejectRefresh answers a refresh with an unknown or expired token, charging
+// it to the client IP.
+func (c *Controllers) rejectRefresh(w http.ResponseWriter, r *http.Request, ip string) {
+	if ok, wait := c.loginIP.Allow(ip); !ok {
+		rateLimit.TooManyRequests(w, r, wait)
+		return
+	}
+	restErrors.Write(w, r, http.StatusUnauthorized, "Refresh token is invalid or expired")
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// tokenResponse builds the answer to a login or refresh.
+func tokenResponse(message string, user databaseTypes.User, tokens authService.Tokens) restTypes.LoginResponse {
+	return restTypes.LoginResponse{
+		Status:       "success",
+		Message:      message,
+		Token:        tokens.AccessToken,
+		ExpiresIn:    int(time.Until(tokens.ExpiresAt).Round(time.Second) / time.Second),
+		RefreshToken: tokens.RefreshToken,
 		UserData: &databaseTypes.User{
 			ID:        user.ID,
 			FirstName: user.FirstName,
@@ -93,8 +271,12 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 			UserType:  user.UserType,
 		},
 	}
-	writeJson(w, resp)
+}
 
+// recordFailedLogin adds a failed login to the audit log under the email
+// that was tried, which may not belong to any account.
+func (c *Controllers) recordFailedLogin(r *http.Request, email string) {
+	c.audit.Record(r, auditService.Change{Action: auditService.LoginFailed, EntityType: auditService.User, EntityID: strings.ToLower(email)})
 }
 
 // TestToken greets the user with "Hello, {userName}!" if he's authorized
@@ -109,178 +291,30 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
 // @Router /auth/testToken [get]
 // @Router /auth/testToken [post]
-func TestToken(w http.ResponseWriter, r *http.Request) {
-	user, err := authService.IsAuthorized(w, r)
+func (c *Controllers) TestToken(w http.ResponseWriter, r *http.Request) {
+	user, err := c.auth.IsAuthorized(w, r)
 	if err.Code == 0 {
 		fmt.Fprintf(w, "HELLO, "+user.FirstName)
 		return
 	}
-	writeJson(w, err)
+	restErrors.WriteResponse(w, r, err)
 
 }
 
-func FoodMenuByHandler(w http.ResponseWriter, r *http.Request) {
-	path := strings.TrimPrefix(r.URL.Path, "/food-menu/")
-	switch r.Method {
-	case "POST":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		food.PostFoodMenuHandler(w, r)
-		break
-	case "PUT":
-		if !authService.IsAuth(w, r) {
-			return
+// limitWrites lets each user's POST, PUT, PATCH and DELETE requests through
+// to next as often as the writes limit allows; reads are not limited. It goes
+// behind Authenticated, which puts the user in the context.
+func (c *Controllers) limitWrites(next http.Handler) http.Handler {
+	return c.writes.Middleware(func(r *http.Request) string {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return ""
 		}
-		food.PutFoodMenuHandler(w, r, path)
-		break
-	case "DELETE":
-		if !authService.IsAuth(w, r) {
-			return
+		user, ok := authService.UserFromContext(r.Context())
+		if !ok {
+			return ""
 		}
-		food.DeleteFoodMenu(w, r, path)
-		break
-	case "GET":
-		dateStr := strings.TrimPrefix(r.URL.Path, "/data/food-menu/")
-		fmt.Println(dateStr)
-		if dateStr == "all" {
-			food.GetAllFoodMenus(w, r)
-			break
-		}
-		if dateStr != "" {
-			food.GetFoodMenuByDate(w, r)
-			break
-		}
-		food.GetFoodMenu(w, r)
-		break
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-}
-
-// ScheduleImageHandler handles the image requests for the daily schedule.
-func ScheduleImageHandler(w http.ResponseWriter, r *http.Request) {
-	fmt.Println(r.Method)
-	switch r.Method {
-	case "GET":
-		dailySchedule.GetDailyImage(w, r)
-	case "POST":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		dailySchedule.PostDailyImage(w, r)
-	case "DELETE":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		dailySchedule.DeleteDailyImage(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-}
-
-func ScheduleHandler(w http.ResponseWriter, r *http.Request) {
-
-	switch r.Method {
-	case "POST":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		dailySchedule.PostDailySchedule(w, r)
-		break
-	case "PUT":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		dailySchedule.PutDailySchedule(w, r)
-		break
-	case "DELETE":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		dailySchedule.DeleteDailySchedule(w, r)
-		break
-	case "GET":
-		dailySchedule.GetEventsByDate(w, r)
-		break
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-}
-
-func LostAndFoundHandler(w http.ResponseWriter, r *http.Request) {
-
-	switch r.Method {
-	case "POST":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		lostAndFound.PostLostAndFoundItem(w, r)
-		break
-	case "GET":
-		imageID := strings.TrimPrefix(r.URL.Path, "/data/lost-and-found/image/")
-		fmt.Println(imageID)
-		if imageID != "/data/lost-and-found/" {
-			lostAndFound.GetLostAndFoundImageHandler(w, r)
-			return
-		}
-		lostAndFound.GetLostAndFoundItemsHandler(w, r)
-	case "PUT":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		lostAndFound.PutLostAndFoundItem(w, r)
-		break
-	case "DELETE":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		lostAndFound.HandleDeleteLostAndFound(w, r)
-	default:
-		lostAndFound.GetLostAndFoundItemsHandler(w, r)
-		break
-	}
-}
-
-func SportsHandler(w http.ResponseWriter, r *http.Request) {
-	sports.GetSportsData(w, r)
-}
-
-func GamesHandler(w http.ResponseWriter, r *http.Request) {
-	sports.GetSportsGameData(w, r)
-}
-
-func SchoolStoreHandler(w http.ResponseWriter, r *http.Request) {
-
-	switch r.Method {
-	case "GET":
-
-		imageID := strings.TrimPrefix(r.URL.Path, "/data/school-store/")
-		fmt.Println(imageID)
-		if imageID != "" {
-			schoolStore.HandleSchoolStoreImage(w, r)
-			return
-		}
-		schoolStore.HandleSchoolStore(w, r)
-	case "POST":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		schoolStore.HandleAddSchoolStoreItem(w, r)
-	case "PUT":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		schoolStore.PutSchoolStoreItem(w, r)
-	case "DELETE":
-		if !authService.IsAuth(w, r) {
-			return
-		}
-		schoolStore.HandleDeleteSchoolStoreItem(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
+		return strconv.Itoa(user.ID)
+	})(next)
 }
@@ -3,65 +3,84 @@ package lostAndFound
 import (
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"server/auditService"
 	"server/authService"
-	"server/databaseTypes"
+	"server/databaseControllers"
+	"server/httpCache"
+	"server/listQuery"
+	"server/restErrors"
 	"server/restTypes"
+	"server/router"
+	"server/validation"
 	"strconv"
-	"strings"
 )
 
-// GetLostAndFoundItemsHandler retrieves a list of all lost and found items from the database and returns them as a JSON array in the response body.
-// @Summary Get all lost and found items
-// @Description Retrieves a list of all lost and found items from the database and returns them as a JSON array in the response body.
+// Handler serves the lost and found endpoints.
+type Handler struct {
+	items databaseControllers.LostAndFoundRepo
+	audit *auditService.Service
+}
+
+// NewHandler returns a Handler backed by items, recording changes to audit.
+func NewHandler(items databaseControllers.LostAndFoundRepo, audit *auditService.Service) *Handler {
+	return &Handler{items: items, audit: audit}
+}
+
+// snapshot returns the stored item, without its image, for the audit log,
+// or nil if there is none.
+func (h *Handler) snapshot(id int) interface{} {
+	item, err := h.items.Get(id)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
+// GetLostAndFoundItemsHandler retrieves a page of lost and found items from the database and returns them in the response body.
+// @Summary Get lost and found items
+// @Description Retrieves a page of lost and found items from the database, optionally filtered and sorted.
 // @Tags LostAndFound
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} databaseTypes.LostAndFound
-// @Failure 401 {string} Unauthorized
-// @Failure 500 {string} Internal Server Error
+// @Param limit query int false "Most items to return, 1 to 200; all of them when absent"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: id, item_name, date_found, location_found, status" default(-date_found)
+// @Param status query int false "Only items with this status: 0 lost, 1 found" Enums(0, 1)
+// @Param location_found query string false "Only items found at this location"
+// @Param date_from query string false "Only items found on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Only items found on or before this date (YYYY-MM-DD)"
+// @Success 200 {object} restTypes.LostAndFoundResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/lost-and-found/ [get]
-func GetLostAndFoundItemsHandler(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetLostAndFoundItemsHandler(w http.ResponseWriter, r *http.Request) {
 
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	q, errs := listQuery.ParseUnpaged(r.URL.Query(), databaseControllers.LostAndFoundListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer db.Close()
 
-	// Execute query to get lost and found items
-	rows, err := db.Query("SELECT id, item_name, description, date_found, location_found, status FROM LostAndFound")
+	// Get the page of lost and found items
+	items, total, err := h.items.List(q)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		restErrors.Internal(w, r, "listing lost and found items", err)
 		return
 	}
-	defer rows.Close()
 
 	// Create response struct
 	response := restTypes.LostAndFoundResponse{
-		Items: []databaseTypes.LostAndFound{},
-	}
-
-	// Parse rows into response struct
-	for rows.Next() {
-		var item databaseTypes.LostAndFound
-		err := rows.Scan(&item.ID, &item.ItemName, &item.Description, &item.DateFound, &item.LocationFound, &item.Status)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		item.ImageURL = fmt.Sprintf("/data/lost-and-found/image/%d", item.ID)
-		response.Items = append(response.Items, item)
+		Items: items,
+		Page:  q.Page(r, total),
 	}
 
 	// Marshal response into JSON
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding lost and found items", err)
 		return
 	}
 
@@ -80,39 +99,35 @@ func GetLostAndFoundItemsHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags LostAndFound
 // @Accept  json
 // @Produce  image/jpeg
-// @Param imageID path string true "The ID of the lost and found item to retrieve the image file for."
+// @Param id path int true "The ID of the lost and found item to retrieve the image file for."
 // @Success 200 {string} binary "The image file for the specified lost and found item."
-// @Failure 404 {string} Not Found "The specified lost and found item ID was not found in the database."
-// @Failure 500 {string} Internal Server Error
-// @Router /data/lost-and-found/image/{imageID} [get]
-func GetLostAndFoundImageHandler(w http.ResponseWriter, r *http.Request) {
-	imageID := strings.TrimPrefix(r.URL.Path, "/data/lost-and-found/image/")
-
-	// Fetch image from database
-	db, err := sql.Open("sqlite3", "database.db")
+// @Failure 404 {object} restTypes.ErrorResponse "The specified lost and found item ID was not found in the database."
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /data/lost-and-found/image/{id} [get]
+func (h *Handler) GetLostAndFoundImageHandler(w http.ResponseWriter, r *http.Request) {
+	imageID, err := router.IntParam(r, "id")
 	if err != nil {
-		log.Fatal(err)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
+		return
 	}
-	defer db.Close()
-
-	query := "SELECT image_file FROM LostAndFound WHERE id = ?"
-	row := db.QueryRow(query, imageID)
 
-	var image []byte
-	err = row.Scan(&image)
-	if err != nil {
-		log.Fatal(err)
+	// Fetch image from database
+	image, err := h.items.GetImage(imageID)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "loading lost and found image", err)
+		return
 	}
 
 	// Set response headers
 	w.Header().Set("Content-Type", "image/jpeg")
-	w.Header().Set("Content-Length", strconv.Itoa(len(image)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(image.Data)))
+	httpCache.SetLastModified(w, image.UpdatedAt)
 
 	// Write image data to response
-	_, err = w.Write(image)
-	if err != nil {
-		log.Fatal(err)
-	}
+	w.Write(image.Data)
 }
 
 // PostLostAndFoundItem Add a lost and found item
@@ -120,68 +135,46 @@ func GetLostAndFoundImageHandler(w http.ResponseWriter, r *http.Request) {
 // @Description Adds a lost and found item to the database and uploads an image file
 // @Tags LostAndFound
 // @Accept multipart/form-data
-// @Security Bearer
+// @Security Bearer[lost_and_found:create]
 // @Produce  json
 // @Param item_name formData string true "Name of the lost/found item"
 // @Param description formData string false "Description of the lost/found item"
-// @Param date_found formData string true "Date the item was found"
+// @Param date_found formData string true "Date the item was found (YYYY-MM-DD or RFC 3339)"
 // @Param location_found formData string true "Location where the item was found"
-// @Param status formData string true "Status of the item (lost or found)"
+// @Param status formData int true "Status of the item: 0 lost, 1 found" Enums(0, 1)
 // @Param image_file formData file true "Image of the lost/found item"
 // @Success 201 {object} restTypes.LostAndFoundPostResponse
-// @Failure 400 {object} restTypes.LostAndFoundErrorResponse
-// @Failure 401 {string} Unauthorized
-// @Failure 500 {object} restTypes.LostAndFoundErrorResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/lost-and-found/ [post]
-func PostLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) PostLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
 
 	// Parse form data
 	err := r.ParseMultipartForm(30 << 20) // 30 MB max file size
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to parse form data"}
-		json.NewEncoder(w).Encode(errorResponse)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse form data")
 		return
 	}
 
-	// Parse JSON input
-	var lostAndFound restTypes.LostAndFoundInput
-	lostAndFound.ItemName = r.FormValue("item_name")
-	if lostAndFound.ItemName == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Item name is required"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	lostAndFound.Description = r.FormValue("description")
-	lostAndFound.DateFound = r.FormValue("date_found")
-	if lostAndFound.DateFound == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Date found is required"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	lostAndFound.LocationFound = r.FormValue("location_found")
-	if lostAndFound.LocationFound == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Location found is required"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
+	// Read and validate the form fields
+	lostAndFound := restTypes.LostAndFoundInput{
+		ItemName:      r.FormValue("item_name"),
+		Description:   r.FormValue("description"),
+		DateFound:     r.FormValue("date_found"),
+		LocationFound: r.FormValue("location_found"),
+		Status:        r.FormValue("status"),
 	}
-
-	lostAndFound.Status = r.FormValue("status")
-	if lostAndFound.Status == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Status is required"}
-		json.NewEncoder(w).Encode(errorResponse)
+	if errs := validation.Struct(&lostAndFound); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
 
 	file, _, err := r.FormFile("image_file")
 	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "image_file is required")
 		return
 	}
 	defer file.Close()
@@ -189,63 +182,19 @@ func PostLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
 	// Read the image data from the file
 	image, err := ioutil.ReadAll(file)
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "reading uploaded image", err)
 		return
 	}
 
 	// Insert lost and found item into database
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to open database connection"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	defer db.Close()
-
-	stmt, err := db.Prepare("INSERT INTO LostAndFound (item_name, description, date_found, location_found, status, image_file, submitter_id) VALUES (?, ?, ?, ?, ?, ?, ?)")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to prepare statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	defer stmt.Close()
-	user, _ := authService.IsAuthorized(w, r)
+	user, _ := authService.UserFromContext(r.Context())
 	submitterID := user.ID
-	result, err := stmt.Exec(lostAndFound.ItemName, lostAndFound.Description, lostAndFound.DateFound, lostAndFound.LocationFound, lostAndFound.Status, image, submitterID)
-	if err != nil {
-		fmt.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to execute statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to get rows affected"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-
-	}
-
-	if rowsAffected == 0 {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "No rows were affected"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	// Get ID of the newly inserted row
-	id, err := result.LastInsertId()
+	id, err := h.items.Create(lostAndFound, image, submitterID)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to get last insert ID"}
-		json.NewEncoder(w).Encode(errorResponse)
+		restErrors.Internal(w, r, "creating lost and found item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Create, EntityType: auditService.LostAndFound, EntityID: id, After: h.snapshot(int(id))})
 
 	// Return response
 	response := restTypes.LostAndFoundPostResponse{
@@ -264,56 +213,56 @@ func PostLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
 // @Tags LostAndFound
 // @ID update-lost-and-found-item
 // @Accept json
-// @Security Bearer
+// @Security Bearer[lost_and_found:manage]
 // @Produce json
 // @Param id path int true "Lost and found item ID"
 // @Param item_name formData string false "Item name"
 // @Param description formData string false "Item description"
-// @Param date_found formData string false "Date the item was found"
+// @Param date_found formData string false "Date the item was found (YYYY-MM-DD or RFC 3339)"
 // @Param location_found formData string false "Location where the item was found"
-// @Param status formData string false "Status of the lost and found item"
+// @Param status formData int false "Status of the item: 0 lost, 1 found" Enums(0, 1)
 // @Param image_file formData file false "Image file of the lost and found item"
-// @Success 200 {object} restTypes.LostAndFoundErrorResponse
-// @Failure 400 {object} restTypes.LostAndFoundErrorResponse
-// @Failure 500 {object} restTypes.LostAndFoundErrorResponse
-// @Router /data/lost-and-found/image/{id} [put]
-func PutLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
+// @Success 200 {object} restTypes.LostAndFoundPostResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
+// @Router /data/lost-and-found/{id} [put]
+func (h *Handler) PutLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
+	// Get the ID of the item to be updated from the URL parameter before
+	// reading the body
+	id, err := router.IntParam(r, "id")
+	if err != nil {
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
 
 	// Parse form data
-	err := r.ParseMultipartForm(30 << 20) // 30 MB max file size
+	err = r.ParseMultipartForm(30 << 20) // 30 MB max file size
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to parse form data"}
-		json.NewEncoder(w).Encode(errorResponse)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse form data")
 		return
 	}
 
-	// Parse JSON input
-	var itemName sql.NullString
-	itemName.String = r.FormValue("item_name")
-	if itemName.String != "" {
-		itemName.Valid = true
-	}
-	var description sql.NullString
-	description.String = r.FormValue("description")
-	if description.String != "" {
-		description.Valid = true
-	}
-	var dateFound sql.NullString
-	dateFound.String = r.FormValue("date_found")
-	if dateFound.String != "" {
-		dateFound.Valid = true
+	// Validate the fields that are being changed
+	input := restTypes.LostAndFoundInput{
+		ItemName:      r.FormValue("item_name"),
+		Description:   r.FormValue("description"),
+		DateFound:     r.FormValue("date_found"),
+		LocationFound: r.FormValue("location_found"),
+		Status:        r.FormValue("status"),
 	}
-	var locationFound sql.NullString
-	locationFound.String = r.FormValue("location_found")
-	if locationFound.String != "" {
-		locationFound.Valid = true
+	var fields []string
+	for _, field := range []string{"item_name", "description", "date_found", "location_found", "status"} {
+		if r.FormValue(field) != "" {
+			fields = append(fields, field)
+		}
 	}
-	var status sql.NullString
-	status.String = r.FormValue("status")
-	if status.String != "" {
-		status.Valid = true
+	if errs := validation.Only(&input, fields...); len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
 	}
+
 	file, _, err := r.FormFile("image_file")
 	var img []byte
 	if err == nil {
@@ -322,74 +271,30 @@ func PutLostAndFoundItem(w http.ResponseWriter, r *http.Request) {
 		// Read the image data from the file
 		img, err = ioutil.ReadAll(file)
 		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			restErrors.Internal(w, r, "reading uploaded image", err)
 			return
 		}
 
 	}
 
-	// Get the ID of the item to be updated from the URL parameter
-	idStr := strings.TrimPrefix(r.URL.Path, "/data/lost-and-found/image/")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Invalid ID"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	// Get the current values of the lost and found item from the database
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to open database connection"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	defer db.Close()
-
-	row := db.QueryRow("SELECT item_name, description, date_found, location_found, status, image_file FROM LostAndFound WHERE id=?", id)
-	var currentItemName, currentDescription, currentDateFound, currentLocationFound, currentStatus string
-	var currentImage []byte
-	err = row.Scan(&currentItemName, &currentDescription, &currentDateFound, &currentLocationFound, &currentStatus, &currentImage)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to get current values of lost and found item"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
 	// Update the lost and found item in the database
-	stmt, err := db.Prepare("UPDATE LostAndFound SET item_name=COALESCE(?,?), description=COALESCE(?,?), date_found=COALESCE(?,?), location_found=COALESCE(?,?), status=COALESCE(?,?), image_file=COALESCE(?,?) WHERE id=?")
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to prepare statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	defer stmt.Close()
-	result, err := stmt.Exec(itemName, currentItemName, description, currentDescription, dateFound, currentDateFound, locationFound, currentLocationFound, status, currentStatus, img, currentImage, id)
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to execute statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "Failed to get rows affected"}
-		json.NewEncoder(w).Encode(errorResponse)
+	before := h.snapshot(id)
+	err = h.items.Update(id, databaseControllers.LostAndFoundUpdate{
+		ItemName:      given(input.ItemName),
+		Description:   given(input.Description),
+		DateFound:     given(input.DateFound),
+		LocationFound: given(input.LocationFound),
+		Status:        given(input.Status),
+		ImageFile:     img,
+	})
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
 		return
-	}
-
-	if rowsAffected == 0 {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.LostAndFoundErrorResponse{Error: "No rows were affected"}
-		json.NewEncoder(w).Encode(errorResponse)
+	} else if err != nil {
+		restErrors.Internal(w, r, "updating lost and found item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Update, EntityType: auditService.LostAndFound, EntityID: id, Before: before, After: h.snapshot(id)})
 
 	// Return response
 	response := restTypes.LostAndFoundPostResponse{
@@ -410,58 +315,36 @@ type deleteResponse struct {
 // @Summary Delete a lost and found item
 // @Description Deletes a lost and found item from the database
 // @Tags LostAndFound
+// @Security Bearer[lost_and_found:manage]
 // @ID delete-lost-and-found-item
 // @Accept json
 // @Produce json
 // @Param id path int true "Item ID"
 // @Success 200 {object} deleteResponse
-// @Failure 400 {string} string "Invalid item ID"
-// @Failure 404 {string} string "Item not found"
-// @Failure 405 {string} string "Method not allowed"
-// @Failure 500 {string} string "Internal server error"
-// @Router /lost-and-found/{id} [delete]
-func HandleDeleteLostAndFound(w http.ResponseWriter, r *http.Request) {
-	// Check if the request method is DELETE
-	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Parse the item_id from the URL path
-	urlPath := strings.Split(r.URL.Path, "/")
-	itemID := urlPath[len(urlPath)-1]
-
-	// Check if the item_id is valid
-	if _, err := strconv.Atoi(itemID); err != nil {
-		http.Error(w, "Invalid item ID", http.StatusBadRequest)
-		return
-	}
-
-	// Connect to the database
-	db, err := sql.Open("sqlite3", "database.db")
+// @Failure 400 {object} restTypes.ErrorResponse "Invalid item ID"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "Item not found"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
+// @Router /data/lost-and-found/{id} [delete]
+func (h *Handler) HandleDeleteLostAndFound(w http.ResponseWriter, r *http.Request) {
+	// Parse the item ID from the URL path
+	id, err := router.IntParam(r, "id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
 		return
 	}
-	defer db.Close()
 
 	// Delete the item from the LostAndFound table
-	result, err := db.Exec("DELETE FROM LostAndFound WHERE id=?", itemID)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Check if the item was deleted successfully
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	before := h.snapshot(id)
+	err = h.items.Delete(id)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
 		return
-	}
-	if rowsAffected == 0 {
-		http.Error(w, "Item not found", http.StatusNotFound)
+	} else if err != nil {
+		restErrors.Internal(w, r, "deleting lost and found item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Delete, EntityType: auditService.LostAndFound, EntityID: id, Before: before})
 
 	// Create the response struct
 	response := deleteResponse{Status: "Item deleted successfully"}
@@ -469,10 +352,15 @@ func HandleDeleteLostAndFound(w http.ResponseWriter, r *http.Request) {
 	// Return a JSON response with the status of the operation
 	jsonBytes, err := json.Marshal(response)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding response", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	w.Write(jsonBytes)
 }
+
+// given treats an empty form value as absent, keeping the stored value.
+func given(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
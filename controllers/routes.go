@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"net/http"
+	"server/apiVersion"
+	"server/authService"
+	"server/router"
+)
+
+// Routes declares every API route on rt. Version 1 is served under /v1 and,
+// for the clients shipped before versioning, at the unversioned paths;
+// version 2 under /v2 differs from it only in its food menus. Routes
+// changing data require a user whose role has the permission to; see
+// authService.Can. They are rate limited per user, and logins per client IP
+// and account.
+func (c *Controllers) Routes(rt *router.Router) {
+	rt.HandleFunc(http.MethodGet, "/healthz", c.Healthz)
+	rt.HandleFunc(http.MethodGet, "/readyz", c.Readyz)
+
+	for _, version := range []string{apiVersion.Legacy, "v1"} {
+		api := c.version(rt, version)
+		c.menuRoutesV1(api)
+		c.sharedRoutes(api)
+	}
+	v2 := c.version(rt, "v2")
+	c.menuRoutesV2(v2)
+	c.sharedRoutes(v2)
+}
+
+// version returns the group of the routes of an API version, which
+// announces its deprecation once configured.
+func (c *Controllers) version(rt *router.Router, version string) *router.Group {
+	return rt.Group(apiVersion.Prefix(version), apiVersion.Middleware(version, c.api.Deprecated))
+}
+
+// can returns the middleware of a route requiring the permission. The
+// sessions of administrators are also looked up in the database, so one
+// ended by another server process stops working at once; see
+// authService.Service.Live.
+func (c *Controllers) can(permission authService.Permission) router.Middleware {
+	require := c.auth.Require(permission)
+	return func(next http.Handler) http.Handler {
+		next = c.limitWrites(next)
+		if permission == authService.Admin {
+			next = c.auth.Live(next)
+		}
+		return require(next)
+	}
+}
+
+// signedIn is the middleware of a route open to every logged in user. These
+// manage sessions, so the session is looked up in the database as for
+// administrators.
+func (c *Controllers) signedIn(next http.Handler) http.Handler {
+	return c.auth.Authenticated(c.auth.Live(c.limitWrites(next)))
+}
+
+// menuRoutesV1 declares the food menu routes, whose meals are JSON encoded strings.
+func (c *Controllers) menuRoutesV1(api *router.Group) {
+	api.HandleFunc(http.MethodGet, "/data/food-menu/", c.food.GetFoodMenu)
+	api.HandleFunc(http.MethodPost, "/data/food-menu/", c.food.PostFoodMenuHandler, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodGet, "/data/food-menu/all", c.food.GetAllFoodMenus)
+	api.HandleFunc(http.MethodPost, "/data/food-menu/import", c.food.ImportFoodMenus, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodGet, "/data/food-menu/{date}", c.food.GetFoodMenuByDate)
+	api.HandleFunc(http.MethodPut, "/data/food-menu/{date}", c.food.PutFoodMenuHandler, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodDelete, "/data/food-menu/{date}", c.food.DeleteFoodMenu, c.can(authService.FoodMenuWrite))
+}
+
+// menuRoutesV2 declares the food menu routes of version 2, whose meals are
+// lists of dishes. Imports keep the format of the scraped menus.
+func (c *Controllers) menuRoutesV2(api *router.Group) {
+	api.HandleFunc(http.MethodGet, "/data/food-menu/", c.food.GetFoodMenuV2)
+	api.HandleFunc(http.MethodPost, "/data/food-menu/", c.food.PostFoodMenuV2, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodGet, "/data/food-menu/all", c.food.GetAllFoodMenusV2)
+	api.HandleFunc(http.MethodPost, "/data/food-menu/import", c.food.ImportFoodMenus, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodGet, "/data/food-menu/{date}", c.food.GetFoodMenuByDateV2)
+	api.HandleFunc(http.MethodPut, "/data/food-menu/{date}", c.food.PutFoodMenuV2, c.can(authService.FoodMenuWrite))
+	api.HandleFunc(http.MethodDelete, "/data/food-menu/{date}", c.food.DeleteFoodMenu, c.can(authService.FoodMenuWrite))
+}
+
+// sharedRoutes declares the routes every version serves the same way.
+func (c *Controllers) sharedRoutes(api *router.Group) {
+	api.HandleFunc(http.MethodPost, "/auth/login", c.LoginHandler, c.loginIP.Middleware(c.clientIP))
+	api.HandleFunc(http.MethodPost, "/auth/refresh", c.RefreshHandler)
+	api.HandleFunc(http.MethodPost, "/auth/logout", c.LogoutHandler, c.signedIn)
+	api.HandleFunc(http.MethodGet, "/auth/sessions", c.GetSessions, c.signedIn)
+	api.HandleFunc(http.MethodDelete, "/auth/sessions", c.DeleteSessions, c.signedIn)
+	api.HandleFunc(http.MethodDelete, "/auth/sessions/{id}", c.DeleteSession, c.signedIn)
+
+	// Events are listed from both paths; clients have used either
+	api.HandleFunc(http.MethodGet, "/data/daily-schedule/", c.schedule.GetEventsByDate)
+	api.HandleFunc(http.MethodGet, "/data/daily-schedule/events", c.schedule.GetEventsByDate)
+	api.HandleFunc(http.MethodPost, "/data/daily-schedule/", c.schedule.PostDailySchedule, c.can(authService.ScheduleWrite))
+	api.HandleFunc(http.MethodPut, "/data/daily-schedule/", c.schedule.PutDailySchedule, c.can(authService.ScheduleWrite))
+	api.HandleFunc(http.MethodDelete, "/data/daily-schedule/", c.schedule.DeleteDailySchedule, c.can(authService.ScheduleWrite))
+	api.HandleFunc(http.MethodGet, "/data/daily-schedule/image", c.schedule.GetDailyImage)
+	api.HandleFunc(http.MethodPost, "/data/daily-schedule/image", c.schedule.PostDailyImage, c.can(authService.ScheduleWrite))
+	api.HandleFunc(http.MethodDelete, "/data/daily-schedule/image", c.schedule.DeleteDailyImage, c.can(authService.ScheduleWrite))
+
+	api.HandleFunc(http.MethodGet, "/data/lost-and-found/", c.lostAndFound.GetLostAndFoundItemsHandler)
+	api.HandleFunc(http.MethodPost, "/data/lost-and-found/", c.lostAndFound.PostLostAndFoundItem, c.can(authService.LostAndFoundCreate))
+	api.HandleFunc(http.MethodGet, "/data/lost-and-found/image/{id}", c.lostAndFound.GetLostAndFoundImageHandler)
+	api.HandleFunc(http.MethodPut, "/data/lost-and-found/{id}", c.lostAndFound.PutLostAndFoundItem, c.can(authService.LostAndFoundManage))
+	// Older clients update items through the image path
+	api.HandleFunc(http.MethodPut, "/data/lost-and-found/image/{id}", c.lostAndFound.PutLostAndFoundItem, c.can(authService.LostAndFoundManage))
+	api.HandleFunc(http.MethodDelete, "/data/lost-and-found/{id}", c.lostAndFound.HandleDeleteLostAndFound, c.can(authService.LostAndFoundManage))
+
+	api.HandleFunc(http.MethodGet, "/data/sports/", c.sports.GetSportsData)
+	api.HandleFunc(http.MethodGet, "/data/games/", c.sports.GetSportsGameData)
+
+	api.HandleFunc(http.MethodGet, "/data/school-store/", c.schoolStore.HandleSchoolStore)
+	api.HandleFunc(http.MethodPost, "/data/school-store/", c.schoolStore.HandleAddSchoolStoreItem, c.can(authService.StoreWrite))
+	api.HandleFunc(http.MethodGet, "/data/school-store/image/{item_id}", c.schoolStore.HandleSchoolStoreImage)
+	api.HandleFunc(http.MethodPut, "/data/school-store/{item_id}", c.schoolStore.PutSchoolStoreItem, c.can(authService.StoreWrite))
+	api.HandleFunc(http.MethodDelete, "/data/school-store/{item_id}", c.schoolStore.HandleDeleteSchoolStoreItem, c.can(authService.StoreWrite))
+
+	api.HandleFunc(http.MethodGet, "/admin/audit", c.admin.GetAuditLog, c.can(authService.Admin))
+	api.HandleFunc(http.MethodPost, "/admin/backups", c.admin.PostBackup, c.can(authService.Admin))
+	api.HandleFunc(http.MethodPost, "/admin/import-people", c.admin.PostImportPeople, c.can(authService.Admin))
+	api.HandleFunc(http.MethodGet, "/admin/trash", c.admin.GetTrash, c.can(authService.Admin))
+	api.HandleFunc(http.MethodPost, "/admin/trash/{entity_type}/{id}/restore", c.admin.PostRestore, c.can(authService.Admin))
+	api.HandleFunc(http.MethodDelete, "/admin/trash/{entity_type}/{id}", c.admin.DeleteTrashItem, c.can(authService.Admin))
+	api.HandleFunc(http.MethodGet, "/admin/users/{id}/sessions", c.admin.GetUserSessions, c.can(authService.Admin))
+	api.HandleFunc(http.MethodDelete, "/admin/users/{id}/sessions", c.admin.DeleteUserSessions, c.can(authService.Admin))
+}
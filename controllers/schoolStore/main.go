@@ -4,62 +4,73 @@ import (
 	"database/sql"
 	"encoding/json"
 	_ "errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
+	"server/auditService"
+	"server/databaseControllers"
 	"server/databaseTypes"
+	"server/httpCache"
+	"server/listQuery"
+	"server/restErrors"
 	"server/restTypes"
+	"server/router"
+	"server/validation"
 	"strconv"
-	"strings"
 )
 
+// Handler serves the school store endpoints.
+type Handler struct {
+	items databaseControllers.StoreRepo
+	audit *auditService.Service
+}
+
+// NewHandler returns a Handler backed by items, recording changes to audit.
+func NewHandler(items databaseControllers.StoreRepo, audit *auditService.Service) *Handler {
+	return &Handler{items: items, audit: audit}
+}
+
+// snapshot returns the stored product, without its image, for the audit
+// log, or nil if there is none.
+func (h *Handler) snapshot(id int) interface{} {
+	item, err := h.items.Get(id)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
 // HandleSchoolStore Get a list of items from the School Store
 // @Summary Get a list of items from the School Store
 // @Description Retrieves a list of items from the School Store database
 // @Tags School Store
 // @Accept  json
 // @Produce  json
+// @Param limit query int false "Most items to return, 1 to 200; all of them when absent"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: id, product_name, price, category, date_added" default(product_name)
+// @Param category query int false "Only items in this category"
+// @Param price_min query number false "Only items costing at least this much"
+// @Param price_max query number false "Only items costing at most this much"
 // @Success 200 {object} restTypes.SchoolStoreResponse
-// @Failure 400 {string} string "Invalid request parameters"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} restTypes.ErrorResponse "Invalid request parameters"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/school-store/ [get]
-func HandleSchoolStore(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+func (h *Handler) HandleSchoolStore(w http.ResponseWriter, r *http.Request) {
+	q, errs := listQuery.ParseUnpaged(r.URL.Query(), databaseControllers.StoreListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer db.Close()
 
-	rows, err := db.Query("SELECT Product_Name, Description, Price, Category, ID FROM School_Store")
+	items, total, err := h.items.List(q)
 	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "listing store items", err)
 		return
 	}
-	defer rows.Close()
-
-	var items []databaseTypes.SchoolStore
-	for rows.Next() {
-		item := databaseTypes.SchoolStore{}
-		err := rows.Scan(&item.ProductName, &item.Description, &item.Price, &item.Category, &item.ID)
-		if err != nil {
-			fmt.Println(err.Error())
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
-		items = append(items, item)
-	}
 
 	response := restTypes.SchoolStoreResponse{
 		List: items,
+		Page: q.Page(r, total),
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	json.NewEncoder(w).Encode(response)
@@ -73,132 +84,124 @@ func HandleSchoolStore(w http.ResponseWriter, r *http.Request) {
 // @Produce  jpeg
 // @Param   item_id    path    int     true        "ID of the item to retrieve the image for"
 // @Success 200 {string} string "OK"
-// @Failure 400 {string} string "Invalid request parameters"
-// @Failure 404 {string} string "Item not found"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} restTypes.ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} restTypes.ErrorResponse "Item not found"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/school-store/image/{item_id} [get]
-func HandleSchoolStoreImage(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
+func (h *Handler) HandleSchoolStoreImage(w http.ResponseWriter, r *http.Request) {
 	// Get item ID from URL parameter
-	itemIDStr := strings.TrimPrefix(r.URL.Path, "/data/school-store/image/")
-	if itemIDStr == "" {
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
-	itemID, err := strconv.Atoi(itemIDStr)
+	itemID, err := router.IntParam(r, "item_id")
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
 		return
 	}
 
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	image, err := h.items.GetImage(itemID)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
 		return
-	}
-	defer db.Close()
-
-	var image []byte
-	err = db.QueryRow("SELECT image_file FROM School_Store WHERE ID = ?", itemID).Scan(&image)
-	if err != nil {
-		http.Error(w, "Not found", http.StatusNotFound)
+	} else if err != nil {
+		restErrors.Internal(w, r, "loading store image", err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "image/jpeg")
-	w.Write(image)
+	httpCache.SetLastModified(w, image.UpdatedAt)
+	w.Write(image.Data)
 }
 
 // HandleAddSchoolStoreItem Add an item to the School Store
 // @Summary Add an item to the School Store
 // @Description Adds a new item to the School Store database
 // @Tags School Store
-// @Security Bearer
+// @Security Bearer[store:write]
 // @Accept  multipart/form-data
 // @Produce  json
 // @Param   item_name    formData    string     true        "Name of the item to add"
-// @Param   description  formData    string     true        "Description of the item to add"
+// @Param   description  formData    string     false       "Description of the item to add"
 // @Param   price        formData    number     true        "Price of the item to add"
-// @Param   category     formData    string     true        "Category of the item to add"
+// @Param   category     formData    int        true        "Category of the item to add"
 // @Param   image_file   formData    file       true        "Image file of the item to add"
-// @Success 200 {object} restTypes.ErrorResponse
-// @Failure 400 {string} string "Invalid request parameters"
-// @Failure 500 {string} string "Internal server error"
+// @Success 200 {object} restTypes.SchoolStorePostResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/school-store/ [post]
-func HandleAddSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
+func (h *Handler) HandleAddSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 	// Parse multipart form
 	err := r.ParseMultipartForm(32 << 20) // Max file size: 32 MB
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse form data")
 		return
 	}
 
-	// Extract form values
-	itemName := r.FormValue("item_name")
-	description := r.FormValue("description")
-	priceStr := r.FormValue("price")
-	category := r.FormValue("category")
-	imageFile, _, err := r.FormFile("image_file")
-	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Bad request", http.StatusBadRequest)
+	// Extract and validate form values
+	input := restTypes.SchoolStoreInput{
+		ItemName:    r.FormValue("item_name"),
+		Description: r.FormValue("description"),
+		Stock:       1,
+	}
+	var errs validation.Errors
+	if input.Price, err = strconv.ParseFloat(r.FormValue("price"), 64); err != nil {
+		errs.Add("price", "must be a number")
+	}
+	if input.Category, err = strconv.Atoi(r.FormValue("category")); err != nil {
+		errs.Add("category", "must be an integer")
+	}
+	for _, err := range validation.Struct(&input) {
+		if !errs.Has(err.Field) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer imageFile.Close()
 
-	// Convert price to float64
-	price, err := strconv.ParseFloat(priceStr, 64)
+	imageFile, _, err := r.FormFile("image_file")
 	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "image_file is required")
 		return
 	}
+	defer imageFile.Close()
 
 	// Read image file into byte slice
 	imageBytes, err := ioutil.ReadAll(imageFile)
 	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to read image_file")
 		return
 	}
 
 	// Insert new item into database
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	defer db.Close()
-
-	_, err = db.Exec("INSERT INTO School_Store (Product_Name, Description, Price, Category, image_file, Date_Added, Stock) VALUES (?, ?, ?, ?, ?, DATE('now'),1)", itemName, description, price, category, imageBytes)
+	id, err := h.items.Create(databaseTypes.SchoolStore{
+		ProductName: input.ItemName,
+		Description: input.Description,
+		Price:       input.Price,
+		Category:    input.Category,
+		Stock:       input.Stock,
+		ImageFile:   imageBytes,
+	})
 	if err != nil {
-		fmt.Println(err.Error())
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		restErrors.Internal(w, r, "creating store item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Create, EntityType: auditService.StoreItem, EntityID: id, After: h.snapshot(int(id))})
 
 	// Send response
-	response := restTypes.ErrorResponse{
-		Code:    200,
+	response := restTypes.SchoolStorePostResponse{
+		Status:  "success",
 		Message: "Item added to School Store",
+		ID:      id,
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
 // PutSchoolStoreItem Update an item in the School Store
 // @Summary Update an item in the School Store
-// @Security Bearer
+// @Security Bearer[store:write]
 // @Description Updates an existing item in the School Store database
 // @Tags School Store
 // @Accept  multipart/form-data
@@ -207,67 +210,63 @@ func HandleAddSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 // @Param   item_name    formData    string      false       "New name of the item"
 // @Param   description  formData    string      false       "New description of the item"
 // @Param   price        formData    number      false       "New price of the item"
-// @Param   category     formData    string      false       "New category of the item"
+// @Param   category     formData    int         false       "New category of the item"
+// @Param   stock        formData    int         false       "New number of the item in stock"
 // @Param   image_file   formData    file        false       "New image file of the item"
 // @Success 200 {object} restTypes.SchoolStorePostResponse
-// @Failure 400 {string} restTypes.SchoolStoreErrorResponse
-// @Failure 404 {string} restTypes.SchoolStoreErrorResponse
-// @Failure 500 {string} restTypes.SchoolStoreErrorResponse
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse
 // @Router /data/school-store/{item_id} [put]
-func PutSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) PutSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 	// Parse form data
 	err := r.ParseMultipartForm(30 << 20) // 30 MB max file size
 	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to parse form data"}
-		json.NewEncoder(w).Encode(errorResponse)
+		restErrors.Write(w, r, http.StatusBadRequest, "Failed to parse form data")
 		return
 	}
 
-	// Parse JSON input
-	var productName sql.NullString
-	productName.String = r.FormValue("item_name")
-	if productName.String != "" {
-		productName.Valid = true
-	}
-	var category sql.NullString
-	category.String = r.FormValue("category")
-	if category.String != "" {
-		category.Valid = true
+	// Parse and validate the fields that are being changed
+	input := restTypes.SchoolStoreInput{
+		ItemName:    r.FormValue("item_name"),
+		Description: r.FormValue("description"),
 	}
-	var price sql.NullFloat64
-	priceStr := r.FormValue("price")
-	if priceStr != "" {
-		priceVal, err := strconv.ParseFloat(priceStr, 64)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Invalid price"}
-			json.NewEncoder(w).Encode(errorResponse)
-			return
+	var errs validation.Errors
+	var fields []string
+	for _, field := range []string{"item_name", "description", "price", "category", "stock"} {
+		value := r.FormValue(field)
+		if value == "" {
+			continue
+		}
+		fields = append(fields, field)
+		switch field {
+		case "price":
+			if input.Price, err = strconv.ParseFloat(value, 64); err != nil {
+				errs.Add(field, "must be a number")
+			}
+		case "category":
+			if input.Category, err = strconv.Atoi(value); err != nil {
+				errs.Add(field, "must be an integer")
+			}
+		case "stock":
+			if input.Stock, err = strconv.Atoi(value); err != nil {
+				errs.Add(field, "must be an integer")
+			}
 		}
-		price.Float64 = priceVal
-		price.Valid = true
 	}
-	var stock sql.NullInt64
-	stockStr := r.FormValue("stock")
-	if stockStr != "" {
-		stockVal, err := strconv.ParseInt(stockStr, 10, 64)
-		if err != nil {
-			fmt.Println(err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Invalid stock"}
-			json.NewEncoder(w).Encode(errorResponse)
-			return
+	for _, err := range validation.Only(&input, fields...) {
+		if !errs.Has(err.Field) {
+			errs = append(errs, err)
 		}
-		stock.Int64 = stockVal
-		stock.Valid = true
 	}
-	var description sql.NullString
-	description.String = r.FormValue("description")
-	if description.String != "" {
-		description.Valid = true
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
+		return
 	}
+	isGiven := func(field string) bool { return r.FormValue(field) != "" }
+
 	file, _, err := r.FormFile("image_file")
 	var img []byte
 	if err == nil {
@@ -276,81 +275,37 @@ func PutSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 		// Read the image data from the file
 		img, err = ioutil.ReadAll(file)
 		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			restErrors.Internal(w, r, "reading uploaded image", err)
 			return
 		}
 
 	}
 
 	// Get the ID of the item to be updated from the URL parameter
-	idStr := strings.TrimPrefix(r.URL.Path, "/data/school-store/")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Invalid ID"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-
-	// Get the current values of the school store item from the database
-	db, err := sql.Open("sqlite3", "database.db")
+	id, err := router.IntParam(r, "item_id")
 	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to open database connection"}
-		json.NewEncoder(w).Encode(errorResponse)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
 		return
 	}
-	defer db.Close()
-
-	row := db.QueryRow("SELECT product_name, category, price, stock, description, image_file FROM School_Store WHERE id=?", id)
-	var currentProductName, currentCategory, currentDescription string
-	var currentPrice sql.NullFloat64
-	var currentStock sql.NullInt64
-	var currentImage []byte
-	err = row.Scan(&currentProductName, &currentCategory, &currentPrice, &currentStock, &currentDescription, &currentImage)
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to get current values of school store item"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
 
-	}
 	// Update the school store item in the database
-	stmt, err := db.Prepare("UPDATE School_Store SET Product_Name=COALESCE(?,?), Category=COALESCE(?,?), Price=COALESCE(?,?), Stock=COALESCE(?,?), Description=COALESCE(?,?), image_file=COALESCE(?, ?) WHERE ID=?")
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to prepare statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	defer stmt.Close()
-	result, err := stmt.Exec(productName, currentProductName, category, currentCategory, price, currentPrice, stock, currentStock, description, currentDescription, img, currentImage, id)
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to execute statement"}
-		json.NewEncoder(w).Encode(errorResponse)
-		return
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "Failed to get rows affected"}
-		json.NewEncoder(w).Encode(errorResponse)
+	before := h.snapshot(id)
+	err = h.items.Update(id, databaseControllers.SchoolStoreUpdate{
+		ProductName: sql.NullString{String: input.ItemName, Valid: isGiven("item_name")},
+		Category:    sql.NullString{String: strconv.Itoa(input.Category), Valid: isGiven("category")},
+		Price:       sql.NullFloat64{Float64: input.Price, Valid: isGiven("price")},
+		Stock:       sql.NullInt64{Int64: int64(input.Stock), Valid: isGiven("stock")},
+		Description: sql.NullString{String: input.Description, Valid: isGiven("description")},
+		ImageFile:   img,
+	})
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
 		return
-	}
-
-	if rowsAffected == 0 {
-		w.WriteHeader(http.StatusInternalServerError)
-		errorResponse := restTypes.SchoolStoreErrorResponse{Error: "No rows were affected"}
-		json.NewEncoder(w).Encode(errorResponse)
+	} else if err != nil {
+		restErrors.Internal(w, r, "updating store item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Update, EntityType: auditService.StoreItem, EntityID: id, Before: before, After: h.snapshot(id)})
 
 	// Return response
 	response := restTypes.SchoolStorePostResponse{
@@ -367,74 +322,42 @@ func PutSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 // @Summary Delete an item from the School Store
 // @Description Deletes an item from the School Store database
 // @Tags School Store
-// @Security Bearer
+// @Security Bearer[store:write]
 // @Produce  json
 // @Param   item_id      path        int         true        "ID of the item to delete"
-// @Success 200 {object} restTypes.ErrorResponse
-// @Failure 400 {string} string "Invalid request parameters"
-// @Failure 404 {string} string "Item not found"
-// @Failure 500 {string} string "Internal server error"
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 400 {object} restTypes.ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} restTypes.ErrorResponse "Forbidden"
+// @Failure 404 {object} restTypes.ErrorResponse "Item not found"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/school-store/{item_id} [delete]
-func HandleDeleteSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "DELETE" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
+func (h *Handler) HandleDeleteSchoolStoreItem(w http.ResponseWriter, r *http.Request) {
 	// Get item ID from URL parameter
-	itemIDStr := strings.TrimPrefix(r.URL.Path, "/data/school-store/")
-	if itemIDStr == "" {
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
-	itemID, err := strconv.Atoi(itemIDStr)
+	itemID, err := router.IntParam(r, "item_id")
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		restErrors.Write(w, r, http.StatusBadRequest, "Invalid item ID")
 		return
 	}
 
 	// Delete item from database
-	db, err := sql.Open("sqlite3", "database.db")
-	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	before := h.snapshot(itemID)
+	err = h.items.Delete(itemID)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Item not found")
 		return
-	}
-	defer db.Close()
-
-	var image []byte
-	err = db.QueryRow("SELECT image_file FROM School_Store WHERE ID = ?", itemID).Scan(&image)
-	if err != nil {
-		http.Error(w, "Not found", http.StatusNotFound)
-		return
-	}
-
-	result, err := db.Exec("DELETE FROM School_Store WHERE ID = ?", itemID)
-	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	if rowsAffected == 0 {
-		http.Error(w, "Not found", http.StatusNotFound)
-		return
-	}
-
-	// Delete image file
-	err = os.Remove("image_store/school_store/" + strconv.Itoa(itemID) + ".jpg")
-	if err != nil && !os.IsNotExist(err) {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	} else if err != nil {
+		restErrors.Internal(w, r, "deleting store item", err)
 		return
 	}
+	h.audit.Record(r, auditService.Change{Action: auditService.Delete, EntityType: auditService.StoreItem, EntityID: itemID, Before: before})
 
 	// Return success message
-	response := restTypes.ErrorResponse{
-		Code:    200,
+	response := restTypes.DeleteResponse{
+		Status:  "success",
 		Message: "Item deleted from the School Store",
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/auditService"
+	"server/authService"
+	"server/databaseControllers"
+	"server/restErrors"
+	"server/restTypes"
+	"server/router"
+)
+
+// LogoutHandler ends the session of the access token.
+//
+// @Summary Log out
+// @Description Ends the session of the access token: its refresh token and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).
+// @Tags Authentication
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
+// @Router /auth/logout [post]
+func (c *Controllers) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	user, _ := authService.UserFromContext(r.Context())
+	err := c.auth.EndSession(user.ID, authService.SessionFromContext(r.Context()))
+	// A session already ended elsewhere is as good as logged out
+	if err != nil && err != databaseControllers.ErrNotFound {
+		restErrors.Internal(w, r, "ending session", err)
+		return
+	}
+	c.audit.Record(r, auditService.Change{Action: auditService.Logout, EntityType: auditService.User, EntityID: user.ID})
+	writeJson(w, r, restTypes.DeleteResponse{Status: "success", Message: "Logged out"})
+}
+
+// GetSessions lists the caller's sessions.
+//
+// @Summary List your sessions
+// @Description Lists the devices you are logged in on, most recently used first. The IP and last use are those of the last login or refresh; current marks the session of this request.
+// @Tags Authentication
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} restTypes.SessionsResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
+// @Router /auth/sessions [get]
+func (c *Controllers) GetSessions(w http.ResponseWriter, r *http.Request) {
+	user, _ := authService.UserFromContext(r.Context())
+	sessions, err := c.auth.Sessions(user.ID)
+	if err != nil {
+		restErrors.Internal(w, r, "listing sessions", err)
+		return
+	}
+	current := authService.SessionFromContext(r.Context())
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(restTypes.SessionsResponse{Items: sessions})
+}
+
+// DeleteSession ends one of the caller's sessions.
+//
+// @Summary End one of your sessions
+// @Description Logs the device of the session out: its refresh token and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).
+// @Tags Authentication
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Session ID"
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 404 {object} restTypes.ErrorResponse "No such session"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
+// @Router /auth/sessions/{id} [delete]
+func (c *Controllers) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	user, _ := authService.UserFromContext(r.Context())
+	id := router.Param(r, "id")
+	err := c.auth.EndSession(user.ID, id)
+	if err == databaseControllers.ErrNotFound {
+		restErrors.Write(w, r, http.StatusNotFound, "Session not found")
+		return
+	} else if err != nil {
+		restErrors.Internal(w, r, "ending session", err)
+		return
+	}
+	c.audit.Record(r, auditService.Change{Action: auditService.RevokeSessions, EntityType: auditService.User, EntityID: user.ID,
+		After: map[string]interface{}{"session": id}})
+	writeJson(w, r, restTypes.DeleteResponse{Status: "success", Message: "Session ended"})
+}
+
+// DeleteSessions ends every session of the caller.
+//
+// @Summary End all your sessions
+// @Description Logs every device out, including this one. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).
+// @Tags Authentication
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} restTypes.DeleteResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 429 {object} restTypes.ErrorResponse "Too Many Requests"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal Server Error"
+// @Router /auth/sessions [delete]
+func (c *Controllers) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	user, _ := authService.UserFromContext(r.Context())
+	n, err := c.auth.EndSessions(user.ID)
+	if err != nil {
+		restErrors.Internal(w, r, "ending sessions", err)
+		return
+	}
+	c.audit.Record(r, auditService.Change{Action: auditService.RevokeSessions, EntityType: auditService.User, EntityID: user.ID,
+		After: map[string]interface{}{"sessions": n}})
+	writeJson(w, r, restTypes.DeleteResponse{Status: "success", Message: "All sessions ended"})
+}
@@ -1,15 +1,24 @@
 package sports
 
 import (
-	"database/sql"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"server/databaseTypes"
+	"server/databaseControllers"
+	"server/listQuery"
+	"server/restErrors"
 	"server/restTypes"
-	"time"
 )
 
+// Handler serves the sports teams and games.
+type Handler struct {
+	sports databaseControllers.SportsRepo
+}
+
+// NewHandler returns a Handler backed by sports.
+func NewHandler(sports databaseControllers.SportsRepo) *Handler {
+	return &Handler{sports: sports}
+}
+
 // GetSportsData Get sports data
 // @Summary Get sports data
 // @Description Retrieves data about sports teams and their coaches.
@@ -17,53 +26,37 @@ import (
 // @Tags SportsData
 // @Accept json
 // @Produce json
+// @Param limit query int false "Most items to return, 1 to 200; all of them when absent"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: id, sport_name, category, season" default(sport_name)
+// @Param sport_name query string false "Only teams of this sport"
+// @Param category query int false "Only teams in this category"
+// @Param season query int false "Only teams of this season"
 // @Success 200 {object} restTypes.SportsDataList "List of sports data"
-// @Failure 401 {string} string "Unauthorized"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/sports/ [get]
-func GetSportsData(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetSportsData(w http.ResponseWriter, r *http.Request) {
 
-	// Connect to the database
-	db, err := sql.Open("sqlite3", "database.db")
-	fmt.Println("1")
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
+	q, errs := listQuery.ParseUnpaged(r.URL.Query(), databaseControllers.SportsInfoListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer db.Close()
-	fmt.Println("2")
-	// Query the databaseTypes.SportsInfo table to get the data
-	rows, err := db.Query("SELECT id, sport_name, category, season, coach_name, coach_contact, roster FROM SportsInfo")
+
+	// Query the SportsInfo table for the page of data
+	list, total, err := h.sports.ListInfo(q)
 	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
+		restErrors.Internal(w, r, "listing sports info", err)
 		return
 	}
-	defer rows.Close()
-
-	// Create a slice of databaseTypes.SportsInfo structs to hold the data
-	var sportsDataList restTypes.SportsDataList
-
-	// Loop through the rows and add the data to the slice
-	for rows.Next() {
-		var sportsData databaseTypes.SportsInfo
-		err := rows.Scan(&sportsData.ID, &sportsData.SportName, &sportsData.Category, &sportsData.Season,
-			&sportsData.CoachName, &sportsData.CoachContact, &sportsData.Roster)
-		if err != nil {
-			fmt.Println(err.Error())
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		// Add the databaseTypes.SportsInfo struct to the slice
-		sportsDataList.List = append(sportsDataList.List, sportsData)
-	}
+	sportsDataList := restTypes.SportsDataList{List: list, Page: q.Page(r, total)}
 
 	// Marshal the slice to JSON
 	jsonData, err := json.Marshal(sportsDataList)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding sports info", err)
 		return
 	}
 
@@ -80,65 +73,39 @@ func GetSportsData(w http.ResponseWriter, r *http.Request) {
 // @Tags SportsData
 // @Accept json
 // @Produce json
+// @Param limit query int false "Most items to return, 1 to 200; all of them when absent"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - for descending: id, sport_name, category, game_schedule" default(game_schedule)
+// @Param sport_name query string false "Only games of this sport"
+// @Param category query int false "Only games in this category"
+// @Param home_or_away query int false "Only home or only away games"
+// @Param date_from query string false "Only games on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Only games on or before this date (YYYY-MM-DD)"
 // @Success 200 {object} restTypes.SportsGameDataList "List of sports game data"
-// @Failure 401 {string} string "Unauthorized"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} restTypes.ErrorResponse
+// @Failure 401 {object} restTypes.ErrorResponse "Unauthorized"
+// @Failure 500 {object} restTypes.ErrorResponse "Internal server error"
 // @Router /data/games/ [get]
-func GetSportsGameData(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetSportsGameData(w http.ResponseWriter, r *http.Request) {
 
-	// Connect to the database
-	db, err := sql.Open("sqlite3", "database.db?parseTime=true")
-	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
+	q, errs := listQuery.ParseUnpaged(r.URL.Query(), databaseControllers.SportsGameListing)
+	if len(errs) > 0 {
+		restErrors.Invalid(w, r, errs)
 		return
 	}
-	defer db.Close()
 
-	// Query the SportsGames table to get the data
-	rows, err := db.Query("SELECT SportsGames.id, SportsGames.sport_name, SportsGames.category, SportsGames.game_location, SportsGames.opponent_school, SportsGames.home_or_away, SportsGames.match_result, SportsGames.coach_comment, strftime(SportsGames.game_schedule) FROM SportsGames")
+	// Query the SportsGames table for the page of data
+	list, total, err := h.sports.ListGames(q)
 	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
+		restErrors.Internal(w, r, "listing sports games", err)
 		return
 	}
-	defer rows.Close()
-
-	// Create a slice of sportsGameData structs to hold the data
-	var sportsGameDataList restTypes.SportsGameDataList
-
-	// Loop through the rows and add the data to the slice
-	for rows.Next() {
-		var sportsGameData databaseTypes.SportsGame
-		var gameSchedule string
-		err := rows.Scan(&sportsGameData.ID, &sportsGameData.SportName, &sportsGameData.Category, &sportsGameData.GameLocation,
-			&sportsGameData.OpponentSchool, &sportsGameData.HomeOrAway, &sportsGameData.MatchResult, &sportsGameData.CoachComment,
-			&gameSchedule)
-		if err != nil {
-			fmt.Println(err.Error())
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		fmt.Println(gameSchedule)
-		layout := "2006-01-02 03:04 PM"
-
-		t, err := time.Parse(layout, gameSchedule)
-		if err != nil {
-			fmt.Println("Error parsing time:", err)
-			return
-		}
-		// Format the game schedule as a string
-		sportsGameData.GameSchedule = t
-
-		// Add the sportsGameData struct to the slice
-		sportsGameDataList.List = append(sportsGameDataList.List, sportsGameData)
-	}
+	sportsGameDataList := restTypes.SportsGameDataList{List: list, Page: q.Page(r, total)}
 
 	// Marshal the slice to JSON
 	jsonData, err := json.Marshal(sportsGameDataList)
 	if err != nil {
-		fmt.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
+		restErrors.Internal(w, r, "encoding sports games", err)
 		return
 	}
 
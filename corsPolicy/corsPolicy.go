@@ -0,0 +1,89 @@
+package corsPolicy
+
+import (
+	"github.com/rs/cors"
+	"net/http"
+	"server/config"
+	"server/logging"
+	"strings"
+	"time"
+)
+
+// The headers browsers may send to the API and read from its responses.
+var (
+	conditionalHeaders = []string{"If-None-Match", "If-Modified-Since", logging.RequestIDHeader}
+	exposedHeaders     = []string{"ETag", "Last-Modified", "Retry-After", logging.RequestIDHeader}
+)
+
+// Middleware applies the CORS policy of cfg to every request. The origins
+// in cfg.AllowedOrigins may call any route with credentials. Any other
+// origin may only read the routes whose pattern, as returned by match, is
+// listed in cfg.PublicRoutes, without credentials.
+func Middleware(cfg config.CORS, match func(path string) string) func(http.Handler) http.Handler {
+	maxAge := int(time.Duration(cfg.MaxAge).Seconds())
+	allowed := originMatcher(cfg.AllowedOrigins)
+	restricted := cors.New(cors.Options{
+		AllowOriginFunc: allowed,
+		AllowedMethods:  []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders:  append([]string{"Authorization", "Content-Type"}, conditionalHeaders...),
+		ExposedHeaders:  exposedHeaders,
+		MaxAge:          maxAge,
+		// Credentials must never be allowed to every origin
+		AllowCredentials: !contains(cfg.AllowedOrigins, "*"),
+	})
+	public := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodHead},
+		AllowedHeaders: conditionalHeaders,
+		ExposedHeaders: exposedHeaders,
+		MaxAge:         maxAge,
+	})
+	publicRoutes := map[string]bool{}
+	for _, pattern := range cfg.PublicRoutes {
+		publicRoutes[pattern] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		restrictedHandler := restricted.Handler(next)
+		publicHandler := public.Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin != "" && !allowed(origin) && publicRoutes[match(r.URL.Path)] {
+				publicHandler.ServeHTTP(w, r)
+				return
+			}
+			restrictedHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originMatcher returns a function reporting whether an origin is listed in
+// origins, where "*" matches any origin and an origin such as
+// "https://*.example.com" any subdomain.
+func originMatcher(origins []string) func(string) bool {
+	return func(origin string) bool {
+		origin = strings.ToLower(origin)
+		for _, allowed := range origins {
+			allowed = strings.ToLower(allowed)
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+			if i := strings.Index(allowed, "*"); i >= 0 {
+				prefix, suffix := allowed[:i], allowed[i+1:]
+				if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
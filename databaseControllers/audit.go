@@ -0,0 +1,82 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"server/databaseTypes"
+	"server/listQuery"
+)
+
+type auditRepo struct {
+	db *timedDB
+}
+
+// AuditListing is how the audit log can be sorted and filtered.
+var AuditListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	// Entries recorded in the same second are listed newest first too
+	Tiebreak: "-id",
+	Filters: map[string]listQuery.Filter{
+		"user_id":     {Expr: "user_id", Type: "int"},
+		"action":      {Expr: "action"},
+		"entity_type": {Expr: "entity_type"},
+		"entity_id":   {Expr: "entity_id"},
+		"from":        {Expr: "created_at", Op: ">=", Type: "datetime"},
+		"to":          {Expr: "created_at", Op: "<", Type: "datetime"},
+	},
+}
+
+func (r *auditRepo) Record(entry databaseTypes.AuditEntry) error {
+	_, err := r.db.Exec("INSERT INTO AuditLog (user_id, action, entity_type, entity_id, before, after, ip, request_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		entry.UserID, entry.Action, entry.EntityType, entry.EntityID, nullJSON(entry.Before), nullJSON(entry.After), entry.IP, entry.RequestID)
+	return err
+}
+
+func (r *auditRepo) List(q listQuery.Query) ([]databaseTypes.AuditEntry, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM AuditLog"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT id, created_at, user_id, action, entity_type, entity_id, before, after, ip, request_id FROM AuditLog"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []databaseTypes.AuditEntry{}
+	for rows.Next() {
+		var entry databaseTypes.AuditEntry
+		var userID sql.NullInt64
+		var before, after sql.NullString
+		err := rows.Scan(&entry.ID, &entry.CreatedAt, &userID, &entry.Action, &entry.EntityType, &entry.EntityID, &before, &after, &entry.IP, &entry.RequestID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if userID.Valid {
+			id := int(userID.Int64)
+			entry.UserID = &id
+		}
+		if before.Valid {
+			entry.Before = []byte(before.String)
+		}
+		if after.Valid {
+			entry.After = []byte(after.String)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
+
+// nullJSON stores an absent snapshot as NULL.
+func nullJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,174 @@
+package databaseControllers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// backupStepPause is how long a backup waits between the chunks it copies.
+const backupStepPause = 20 * time.Millisecond
+
+// BackupTo copies the live database behind db into a new file at destPath
+// using the SQLite online backup API, so the server keeps serving while the
+// copy is made. The copy is written next to destPath first and only renamed
+// into place once it passes an integrity check.
+func BackupTo(db *sql.DB, destPath string) error {
+	tmpPath := destPath + ".tmp"
+	os.Remove(tmpPath)
+	if err := backupInto(db, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := CheckIntegrity(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, destPath)
+}
+
+func backupInto(db *sql.DB, destPath string) error {
+	ctx := context.Background()
+	dest, err := sql.Open("sqlite3", destPath)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	destConn, err := dest.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer destConn.Close()
+	srcConn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return destConn.Raw(func(destDriverConn interface{}) error {
+		return srcConn.Raw(func(srcDriverConn interface{}) error {
+			destSQLite, ok := destDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("backup destination is not a SQLite connection")
+			}
+			srcSQLite, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("backup source is not a SQLite connection")
+			}
+
+			backup, err := destSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			// Copy in chunks, pausing between them so writers get the lock.
+			// A step that finds the database locked copies nothing and is
+			// retried after the pause.
+			for {
+				done, err := backup.Step(256)
+				if err != nil {
+					backup.Finish()
+					return err
+				}
+				if done {
+					break
+				}
+				time.Sleep(backupStepPause)
+			}
+			return backup.Finish()
+		})
+	})
+}
+
+// CheckIntegrity runs PRAGMA integrity_check on the database file at path.
+func CheckIntegrity(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite3", path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("integrity check of %s: %w", path, err)
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return err
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("integrity check of %s: %w", path, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("integrity check of %s failed: %v", path, problems)
+	}
+	return nil
+}
+
+// Restore replaces the database file at dbPath with the backup at
+// backupPath. Both the backup and the copy that is swapped in must pass an
+// integrity check; the replaced database is kept as dbPath+".before-restore".
+// Nothing may have dbPath open while it runs.
+func Restore(backupPath, dbPath string) error {
+	if err := CheckIntegrity(backupPath); err != nil {
+		return err
+	}
+
+	tmpPath := dbPath + ".restoring"
+	if err := copyFile(backupPath, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := CheckIntegrity(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := os.Rename(dbPath, dbPath+".before-restore"); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	// Journals left by the replaced database must not be applied to the restored one
+	os.Remove(dbPath + "-journal")
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+	return os.Rename(tmpPath, dbPath)
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
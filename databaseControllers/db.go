@@ -0,0 +1,51 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ErrNotFound is returned by the repositories when the requested row does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrTokenReused is returned by TokenRepo.Use for a refresh token that was
+// already used once.
+var ErrTokenReused = errors.New("refresh token already used")
+
+// ErrConflict is returned by the repositories when a write would give a row
+// the key of another one.
+var ErrConflict = errors.New("record already exists")
+
+// ErrTrashed is returned by the repositories when a write would give a row
+// the key of one in the trash, which must be restored or purged first.
+var ErrTrashed = errors.New("record with the same key is in the trash")
+
+// Open opens the SQLite database at path and returns a pool that is meant to
+// live for the whole lifetime of the process. Passing ":memory:" opens a
+// private in-memory database, which is useful for tests.
+func Open(path string) (*sql.DB, error) {
+	inMemory := path == ":memory:"
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("sqlite3", path+sep+"_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+	if inMemory {
+		// Every new connection to ":memory:" would get an empty database,
+		// so keep exactly one connection open forever.
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
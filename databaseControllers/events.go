@@ -0,0 +1,115 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"server/restTypes"
+)
+
+type eventRepo struct {
+	db *timedDB
+}
+
+func (r *eventRepo) ListByDate(date string) ([]restTypes.Event, error) {
+	rows, err := r.db.Query("SELECT id, title, description, start, end, status, color, location FROM events WHERE DATE(start)=? AND deleted_at IS NULL", date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []restTypes.Event
+	for rows.Next() {
+		var event restTypes.Event
+		err := rows.Scan(&event.ID, &event.Title, &event.Description, &event.Start, &event.End, &event.Status, &event.Color, &event.Location)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *eventRepo) Get(id string, date string) (*restTypes.Event, error) {
+	var event restTypes.Event
+	err := r.db.QueryRow("SELECT id, title, description, start, end, status, color, location FROM events WHERE id=? AND DATE(start)=? AND deleted_at IS NULL", id, date).
+		Scan(&event.ID, &event.Title, &event.Description, &event.Start, &event.End, &event.Status, &event.Color, &event.Location)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Create adds the event. It returns ErrConflict if there already is an event
+// with the ID and ErrTrashed if the event with the ID is in the trash.
+func (r *eventRepo) Create(event restTypes.Event) error {
+	if err := notTrashed(r.db, "events", "id", event.ID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("INSERT INTO events (id, title, description, start, end, status, color, location) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		event.ID, event.Title, event.Description, event.Start, event.End, event.Status, event.Color, event.Location)
+	return conflictOr(err)
+}
+
+// Update replaces the event with the same ID that starts on the same date.
+func (r *eventRepo) Update(event restTypes.Event) error {
+	date := event.Start.Format("2006-01-02")
+	res, err := r.db.Exec("UPDATE events SET title=?, description=?, start=?, end=?, status=?, color=?, location=? WHERE id=? AND DATE(start)=? AND deleted_at IS NULL",
+		event.Title, event.Description, event.Start, event.End, event.Status, event.Color, event.Location, event.ID, date)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+// Delete moves the event with the ID that starts on the date to the trash.
+func (r *eventRepo) Delete(id string, date string) error {
+	res, err := r.db.Exec("UPDATE events SET deleted_at = CURRENT_TIMESTAMP WHERE id=? AND DATE(start)=? AND deleted_at IS NULL", id, date)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+type scheduleImageRepo struct {
+	db *timedDB
+}
+
+func (r *scheduleImageRepo) GetImage(date string) (*Image, error) {
+	var image Image
+	err := r.db.QueryRow("SELECT image_file, updated_at FROM DailyScheduleImages WHERE date = ? AND deleted_at IS NULL", date).Scan(&image.Data, &image.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// SaveImage replaces the image for the date, or adds one if there is none yet.
+// It returns ErrTrashed if the image for the date is in the trash.
+func (r *scheduleImageRepo) SaveImage(date string, image []byte) error {
+	if err := notTrashed(r.db, "DailyScheduleImages", "date", date); err != nil {
+		return err
+	}
+	res, err := r.db.Exec("UPDATE DailyScheduleImages SET image_file = ? WHERE date = ? AND deleted_at IS NULL", image, date)
+	if err != nil {
+		return err
+	}
+	if rowsAffectedOrNotFound(res) == nil {
+		return nil
+	}
+	_, err = r.db.Exec("INSERT INTO DailyScheduleImages (date, image_file, image_url, file_name) VALUES (?, ?, ?, ?)", date, image, "", "")
+	return err
+}
+
+// DeleteImage moves the image for the date to the trash.
+func (r *scheduleImageRepo) DeleteImage(date string) error {
+	res, err := r.db.Exec("UPDATE DailyScheduleImages SET deleted_at = CURRENT_TIMESTAMP WHERE date = ? AND deleted_at IS NULL", date)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
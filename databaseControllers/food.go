@@ -0,0 +1,139 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"fmt"
+	"server/databaseTypes"
+	"server/listQuery"
+)
+
+type foodMenuRepo struct {
+	db *timedDB
+}
+
+func (r *foodMenuRepo) GetByDate(date string) (*databaseTypes.FoodMenu, error) {
+	menu := databaseTypes.FoodMenu{Date: date}
+	err := r.db.QueryRow("SELECT breakfast, lunch, dinner, updated_at FROM FoodMenu WHERE date = ? AND deleted_at IS NULL", date).
+		Scan(&menu.Breakfast, &menu.Lunch, &menu.Dinner, &menu.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// FoodMenuListing is how the food menu list can be sorted and filtered.
+var FoodMenuListing = listQuery.Spec{
+	Sorts:       map[string]string{"date": "date"},
+	DefaultSort: "date",
+	Tiebreak:    "id",
+	Filters: map[string]listQuery.Filter{
+		"date_from": {Expr: "date", Op: ">=", Type: "date"},
+		"date_to":   {Expr: "date", Op: "<=", Type: "date"},
+	},
+	Scope: "deleted_at IS NULL",
+}
+
+func (r *foodMenuRepo) List(q listQuery.Query) ([]databaseTypes.FoodMenu, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM FoodMenu"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT date, breakfast, lunch, dinner FROM FoodMenu"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	menus := []databaseTypes.FoodMenu{}
+	for rows.Next() {
+		var menu databaseTypes.FoodMenu
+		if err := rows.Scan(&menu.Date, &menu.Breakfast, &menu.Lunch, &menu.Dinner); err != nil {
+			return nil, 0, err
+		}
+		menus = append(menus, menu)
+	}
+	return menus, total, rows.Err()
+}
+
+// Create adds the menu. It returns ErrConflict if there already is a menu
+// for the date and ErrTrashed if the menu for the date is in the trash.
+func (r *foodMenuRepo) Create(menu databaseTypes.FoodMenu) error {
+	if err := notTrashed(r.db, "FoodMenu", "date", menu.Date); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("INSERT INTO FoodMenu (date, breakfast, lunch, dinner) VALUES (?, ?, ?, ?)",
+		menu.Date, menu.Breakfast, menu.Lunch, menu.Dinner)
+	return conflictOr(err)
+}
+
+// Update replaces the menu of date, which may move it to another date.
+// Moving onto the date of another menu returns ErrConflict, and onto that of
+// one in the trash ErrTrashed.
+func (r *foodMenuRepo) Update(date string, menu databaseTypes.FoodMenu) error {
+	if menu.Date != date {
+		if err := notTrashed(r.db, "FoodMenu", "date", menu.Date); err != nil {
+			return err
+		}
+	}
+	res, err := r.db.Exec("UPDATE FoodMenu SET date=?, breakfast=?, lunch=?, dinner=? WHERE date=? AND deleted_at IS NULL",
+		menu.Date, menu.Breakfast, menu.Lunch, menu.Dinner, date)
+	if err != nil {
+		return conflictOr(err)
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+// Delete moves the menu of date to the trash.
+func (r *foodMenuRepo) Delete(date string) error {
+	res, err := r.db.Exec("UPDATE FoodMenu SET deleted_at = CURRENT_TIMESTAMP WHERE date = ? AND deleted_at IS NULL", date)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+func (r *foodMenuRepo) UpsertAll(menus []databaseTypes.FoodMenu, dryRun bool) ([]MenuChange, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// Rolling back after a commit is a no-op
+	defer tx.Rollback()
+
+	changes := make([]MenuChange, 0, len(menus))
+	for _, menu := range menus {
+		var current databaseTypes.FoodMenu
+		err := tx.QueryRow("SELECT breakfast, lunch, dinner FROM FoodMenu WHERE date = ? AND deleted_at IS NULL", menu.Date).
+			Scan(&current.Breakfast, &current.Lunch, &current.Dinner)
+		switch {
+		case err == sql.ErrNoRows:
+			if err = notTrashed(tx, "FoodMenu", "date", menu.Date); err != nil {
+				break
+			}
+			_, err = tx.Exec("INSERT INTO FoodMenu (date, breakfast, lunch, dinner) VALUES (?, ?, ?, ?)",
+				menu.Date, menu.Breakfast, menu.Lunch, menu.Dinner)
+			changes = append(changes, MenuInserted)
+		case err != nil:
+		case current.Breakfast == menu.Breakfast && current.Lunch == menu.Lunch && current.Dinner == menu.Dinner:
+			changes = append(changes, MenuUnchanged)
+		default:
+			_, err = tx.Exec("UPDATE FoodMenu SET breakfast=?, lunch=?, dinner=? WHERE date=?",
+				menu.Breakfast, menu.Lunch, menu.Dinner, menu.Date)
+			changes = append(changes, MenuUpdated)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("saving menu for %s: %w", menu.Date, err)
+		}
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+	return changes, tx.Commit()
+}
@@ -0,0 +1,115 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"fmt"
+	"server/databaseTypes"
+	"server/listQuery"
+	"server/restTypes"
+)
+
+type lostAndFoundRepo struct {
+	db *timedDB
+}
+
+// LostAndFoundListing is how the lost and found list can be sorted and filtered.
+var LostAndFoundListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"id":             "id",
+		"item_name":      "item_name",
+		"date_found":     "date_found",
+		"location_found": "location_found",
+		"status":         "status",
+	},
+	DefaultSort: "-date_found",
+	Tiebreak:    "id",
+	Filters: map[string]listQuery.Filter{
+		"status":         {Expr: "status", Type: "int"},
+		"location_found": {Expr: "location_found"},
+		"date_from":      {Expr: "date(date_found)", Op: ">=", Type: "date"},
+		"date_to":        {Expr: "date(date_found)", Op: "<=", Type: "date"},
+	},
+	Scope: "deleted_at IS NULL",
+}
+
+func (r *lostAndFoundRepo) List(q listQuery.Query) ([]databaseTypes.LostAndFound, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM LostAndFound"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT id, item_name, description, date_found, location_found, status FROM LostAndFound"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []databaseTypes.LostAndFound{}
+	for rows.Next() {
+		var item databaseTypes.LostAndFound
+		err := rows.Scan(&item.ID, &item.ItemName, &item.Description, &item.DateFound, &item.LocationFound, &item.Status)
+		if err != nil {
+			return nil, 0, err
+		}
+		item.ImageURL = fmt.Sprintf("/data/lost-and-found/image/%d", item.ID)
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *lostAndFoundRepo) Get(id int) (*databaseTypes.LostAndFound, error) {
+	var item databaseTypes.LostAndFound
+	var submitterID sql.NullInt64
+	err := r.db.QueryRow("SELECT id, item_name, description, date_found, location_found, status, submitter_id FROM LostAndFound WHERE id = ? AND deleted_at IS NULL", id).
+		Scan(&item.ID, &item.ItemName, &item.Description, &item.DateFound, &item.LocationFound, &item.Status, &submitterID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	item.SubmitterID = int(submitterID.Int64)
+	item.ImageURL = fmt.Sprintf("/data/lost-and-found/image/%d", item.ID)
+	return &item, nil
+}
+
+func (r *lostAndFoundRepo) GetImage(id int) (*Image, error) {
+	var image Image
+	err := r.db.QueryRow("SELECT image_file, updated_at FROM LostAndFound WHERE id = ? AND deleted_at IS NULL", id).Scan(&image.Data, &image.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *lostAndFoundRepo) Create(item restTypes.LostAndFoundInput, image []byte, submitterID int) (int64, error) {
+	res, err := r.db.Exec("INSERT INTO LostAndFound (item_name, description, date_found, location_found, status, image_file, submitter_id) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		item.ItemName, item.Description, item.DateFound, item.LocationFound, item.Status, image, submitterID)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *lostAndFoundRepo) Update(id int, update LostAndFoundUpdate) error {
+	res, err := r.db.Exec("UPDATE LostAndFound SET item_name=COALESCE(?, item_name), description=COALESCE(?, description), date_found=COALESCE(?, date_found), location_found=COALESCE(?, location_found), status=COALESCE(?, status), image_file=COALESCE(?, image_file) WHERE id=? AND deleted_at IS NULL",
+		update.ItemName, update.Description, update.DateFound, update.LocationFound, update.Status, update.ImageFile, id)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+// Delete moves the item to the trash.
+func (r *lostAndFoundRepo) Delete(id int) error {
+	res, err := r.db.Exec("UPDATE LostAndFound SET deleted_at = CURRENT_TIMESTAMP WHERE id=? AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
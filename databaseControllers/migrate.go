@@ -0,0 +1,216 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is one versioned schema change, read from
+// migrations/NNNN_name.up.sql and its matching .down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState reports whether a migration has been applied to the database.
+type MigrationState struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrations returns every embedded migration, ordered by version.
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		file := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(file, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(file, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: name must end in .up.sql or .down.sql", file)
+		}
+		base := strings.TrimSuffix(file, "."+direction+".sql")
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_description.%s.sql", file, direction)
+		}
+		body, err := migrationFiles.ReadFile("migrations/" + file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = m
+		} else if m.Name != parts[1] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, parts[1])
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func ensureSchemaVersionTable(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version    INTEGER  PRIMARY KEY,
+		name       TEXT     NOT NULL,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	return err
+}
+
+// MigrationStatus lists every known migration and whether it has been applied.
+func MigrationStatus(db *sql.DB) ([]MigrationState, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureSchemaVersionTable(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		appliedAt, ok := applied[m.Version]
+		states = append(states, MigrationState{Migration: m, Applied: ok, AppliedAt: appliedAt})
+		delete(applied, m.Version)
+	}
+	for version := range applied {
+		return nil, fmt.Errorf("database has migration %d applied, which this binary does not know about", version)
+	}
+	return states, nil
+}
+
+// MigrationsCurrent reports whether every known migration has been applied.
+func MigrationsCurrent(db *sql.DB) (bool, error) {
+	states, err := MigrationStatus(db)
+	if err != nil {
+		return false, err
+	}
+	for _, state := range states {
+		if !state.Applied {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MigrateUp applies every pending migration in version order, each in its own
+// transaction, and returns the ones it applied.
+func MigrateUp(db *sql.DB) ([]Migration, error) {
+	states, err := MigrationStatus(db)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, state := range states {
+		if state.Applied {
+			continue
+		}
+		err := inTx(db, func(tx *sql.Tx) error {
+			if _, err := tx.Exec(state.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec("INSERT INTO schema_version (version, name) VALUES (?, ?)", state.Version, state.Name)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("applying migration %04d_%s: %w", state.Version, state.Name, err)
+		}
+		done = append(done, state.Migration)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the last steps applied migrations, newest first, and
+// returns the ones it reverted.
+func MigrateDown(db *sql.DB, steps int) ([]Migration, error) {
+	states, err := MigrationStatus(db)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for i := len(states) - 1; i >= 0 && len(done) < steps; i-- {
+		state := states[i]
+		if !state.Applied {
+			continue
+		}
+		err := inTx(db, func(tx *sql.Tx) error {
+			if _, err := tx.Exec(state.Down); err != nil {
+				return err
+			}
+			_, err := tx.Exec("DELETE FROM schema_version WHERE version = ?", state.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("reverting migration %04d_%s: %w", state.Version, state.Name, err)
+		}
+		done = append(done, state.Migration)
+	}
+	return done, nil
+}
+
+// inTx runs fn in a transaction, committing if it succeeds and rolling back otherwise.
+func inTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package databaseControllers
+
+import (
+	"testing"
+)
+
+// newTestRepos returns the repositories of a fresh, fully migrated
+// in-memory database.
+func newTestRepos(t *testing.T) *Repositories {
+	t.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+	return NewRepositories(db)
+}
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d_%s has version %d, want %d", m.Version, m.Name, m.Version, i+1)
+		}
+		if m.Up == "" || m.Down == "" {
+			t.Errorf("migration %04d_%s is missing its up or down SQL", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := MigrateUp(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("MigrateUp applied %d migrations, want %d", len(applied), len(migrations))
+	}
+	if current, err := MigrationsCurrent(db); err != nil || !current {
+		t.Fatalf("MigrationsCurrent = %v, %v after MigrateUp", current, err)
+	}
+	if applied, err := MigrateUp(db); err != nil || len(applied) != 0 {
+		t.Fatalf("second MigrateUp = %d, %v, want nothing applied", len(applied), err)
+	}
+
+	// Every down migration must undo its up migration well enough for it
+	// to apply again
+	reverted, err := MigrateDown(db, len(migrations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reverted) != len(migrations) {
+		t.Fatalf("MigrateDown reverted %d migrations, want %d", len(reverted), len(migrations))
+	}
+	if current, err := MigrationsCurrent(db); err != nil || current {
+		t.Fatalf("MigrationsCurrent = %v, %v after MigrateDown", current, err)
+	}
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+}
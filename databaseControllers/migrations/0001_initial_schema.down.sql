@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS SportsGames;
+DROP TABLE IF EXISTS SportsInfo;
+DROP TABLE IF EXISTS School_Store;
+DROP TABLE IF EXISTS LostAndFound;
+DROP TABLE IF EXISTS DailyScheduleImages;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS FoodMenu;
+DROP TABLE IF EXISTS LoginTokens;
+DROP TABLE IF EXISTS Users;
@@ -0,0 +1,91 @@
+-- Tables as the handlers query them. IF NOT EXISTS lets databases created
+-- before migrations existed adopt this version without losing data.
+
+CREATE TABLE IF NOT EXISTS Users (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_type  INTEGER NOT NULL,
+    first_name TEXT    NOT NULL,
+    last_name  TEXT    NOT NULL,
+    email      TEXT    NOT NULL UNIQUE,
+    password   TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS LoginTokens (
+    token    TEXT     PRIMARY KEY,
+    user_id  INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    added_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_user_id ON LoginTokens (user_id, added_at);
+
+CREATE TABLE IF NOT EXISTS FoodMenu (
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    date      TEXT    NOT NULL UNIQUE,
+    breakfast TEXT    NOT NULL DEFAULT '',
+    lunch     TEXT    NOT NULL DEFAULT '',
+    dinner    TEXT    NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id          TEXT     PRIMARY KEY,
+    title       TEXT     NOT NULL DEFAULT '',
+    description TEXT     NOT NULL DEFAULT '',
+    start       DATETIME NOT NULL,
+    end         DATETIME NOT NULL,
+    status      TEXT     NOT NULL DEFAULT '',
+    color       TEXT     NOT NULL DEFAULT '',
+    location    TEXT     NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_events_start ON events (start);
+
+CREATE TABLE IF NOT EXISTS DailyScheduleImages (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    date       TEXT    NOT NULL UNIQUE,
+    image_file BLOB,
+    image_url  TEXT    NOT NULL DEFAULT '',
+    file_name  TEXT    NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS LostAndFound (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    item_name      TEXT     NOT NULL,
+    description    TEXT     NOT NULL DEFAULT '',
+    date_found     DATETIME NOT NULL,
+    location_found TEXT     NOT NULL,
+    status         INTEGER  NOT NULL,
+    image_file     BLOB,
+    submitter_id   INTEGER  REFERENCES Users (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS School_Store (
+    ID           INTEGER  PRIMARY KEY AUTOINCREMENT,
+    Product_Name TEXT     NOT NULL,
+    Category     INTEGER  NOT NULL,
+    Price        REAL     NOT NULL,
+    Stock        INTEGER  NOT NULL DEFAULT 0,
+    Description  TEXT     NOT NULL DEFAULT '',
+    image_file   BLOB,
+    Date_Added   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS SportsInfo (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    sport_name    TEXT    NOT NULL,
+    category      INTEGER NOT NULL,
+    season        INTEGER NOT NULL,
+    coach_name    TEXT    NOT NULL DEFAULT '',
+    coach_contact TEXT    NOT NULL DEFAULT '',
+    roster        TEXT    NOT NULL DEFAULT ''
+);
+
+-- game_schedule is stored as "2006-01-02 03:04 PM".
+CREATE TABLE IF NOT EXISTS SportsGames (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    sport_name      TEXT    NOT NULL,
+    category        INTEGER NOT NULL,
+    game_location   TEXT    NOT NULL DEFAULT '',
+    opponent_school TEXT    NOT NULL DEFAULT '',
+    home_or_away    INTEGER NOT NULL,
+    match_result    TEXT    NOT NULL DEFAULT '',
+    coach_comment   TEXT    NOT NULL DEFAULT '',
+    game_schedule   TEXT    NOT NULL
+);
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS School_Store_updated;
+DROP TRIGGER IF EXISTS School_Store_inserted;
+ALTER TABLE School_Store DROP COLUMN updated_at;
+DROP TRIGGER IF EXISTS LostAndFound_updated;
+DROP TRIGGER IF EXISTS LostAndFound_inserted;
+ALTER TABLE LostAndFound DROP COLUMN updated_at;
+DROP TRIGGER IF EXISTS DailyScheduleImages_updated;
+DROP TRIGGER IF EXISTS DailyScheduleImages_inserted;
+ALTER TABLE DailyScheduleImages DROP COLUMN updated_at;
+DROP TRIGGER IF EXISTS FoodMenu_updated;
+DROP TRIGGER IF EXISTS FoodMenu_inserted;
+ALTER TABLE FoodMenu DROP COLUMN updated_at;
@@ -0,0 +1,47 @@
+-- updated_at records when each row last changed, for the Last-Modified
+-- header. SQLite cannot add a column defaulting to CURRENT_TIMESTAMP, so
+-- triggers keep it current instead. Existing rows count as changed now.
+
+ALTER TABLE FoodMenu ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE FoodMenu SET updated_at = CURRENT_TIMESTAMP;
+CREATE TRIGGER FoodMenu_inserted AFTER INSERT ON FoodMenu
+BEGIN
+    UPDATE FoodMenu SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER FoodMenu_updated AFTER UPDATE ON FoodMenu WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE FoodMenu SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+
+ALTER TABLE DailyScheduleImages ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE DailyScheduleImages SET updated_at = CURRENT_TIMESTAMP;
+CREATE TRIGGER DailyScheduleImages_inserted AFTER INSERT ON DailyScheduleImages
+BEGIN
+    UPDATE DailyScheduleImages SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER DailyScheduleImages_updated AFTER UPDATE ON DailyScheduleImages WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE DailyScheduleImages SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+
+ALTER TABLE LostAndFound ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE LostAndFound SET updated_at = CURRENT_TIMESTAMP;
+CREATE TRIGGER LostAndFound_inserted AFTER INSERT ON LostAndFound
+BEGIN
+    UPDATE LostAndFound SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER LostAndFound_updated AFTER UPDATE ON LostAndFound WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE LostAndFound SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+
+ALTER TABLE School_Store ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE School_Store SET updated_at = CURRENT_TIMESTAMP;
+CREATE TRIGGER School_Store_inserted AFTER INSERT ON School_Store
+BEGIN
+    UPDATE School_Store SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
+CREATE TRIGGER School_Store_updated AFTER UPDATE ON School_Store WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE School_Store SET updated_at = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid;
+END;
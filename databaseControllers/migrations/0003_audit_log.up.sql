@@ -0,0 +1,19 @@
+-- AuditLog records who changed what. user_id is not a foreign key so the
+-- record outlives the account; before and after are JSON snapshots of the
+-- entity, NULL when it did not exist.
+
+CREATE TABLE IF NOT EXISTS AuditLog (
+    id          INTEGER  PRIMARY KEY AUTOINCREMENT,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id     INTEGER,
+    action      TEXT     NOT NULL,
+    entity_type TEXT     NOT NULL,
+    entity_id   TEXT     NOT NULL DEFAULT '',
+    before      TEXT,
+    after       TEXT,
+    ip          TEXT     NOT NULL DEFAULT '',
+    request_id  TEXT     NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON AuditLog (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_id ON AuditLog (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON AuditLog (entity_type, entity_id, created_at);
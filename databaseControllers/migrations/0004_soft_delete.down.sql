@@ -0,0 +1,17 @@
+DROP VIEW IF EXISTS Trash;
+-- Trashed rows would reappear without the column, so they go first.
+DELETE FROM School_Store WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_school_store_deleted_at;
+ALTER TABLE School_Store DROP COLUMN deleted_at;
+DELETE FROM LostAndFound WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_lost_and_found_deleted_at;
+ALTER TABLE LostAndFound DROP COLUMN deleted_at;
+DELETE FROM DailyScheduleImages WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_daily_schedule_images_deleted_at;
+ALTER TABLE DailyScheduleImages DROP COLUMN deleted_at;
+DELETE FROM events WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_events_deleted_at;
+ALTER TABLE events DROP COLUMN deleted_at;
+DELETE FROM FoodMenu WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_food_menu_deleted_at;
+ALTER TABLE FoodMenu DROP COLUMN deleted_at;
@@ -0,0 +1,34 @@
+-- deleted_at marks rows moved to the trash. They are hidden from every read
+-- until restored, and purged for good once the retention period has passed.
+
+ALTER TABLE FoodMenu ADD COLUMN deleted_at DATETIME;
+CREATE INDEX idx_food_menu_deleted_at ON FoodMenu (deleted_at);
+
+ALTER TABLE events ADD COLUMN deleted_at DATETIME;
+CREATE INDEX idx_events_deleted_at ON events (deleted_at);
+
+ALTER TABLE DailyScheduleImages ADD COLUMN deleted_at DATETIME;
+CREATE INDEX idx_daily_schedule_images_deleted_at ON DailyScheduleImages (deleted_at);
+
+ALTER TABLE LostAndFound ADD COLUMN deleted_at DATETIME;
+CREATE INDEX idx_lost_and_found_deleted_at ON LostAndFound (deleted_at);
+
+ALTER TABLE School_Store ADD COLUMN deleted_at DATETIME;
+CREATE INDEX idx_school_store_deleted_at ON School_Store (deleted_at);
+
+-- Trash lists everything in the trash, identified as in the audit log.
+CREATE VIEW Trash AS
+    SELECT 'food_menu' AS entity_type, date AS entity_id, date AS name, deleted_at
+        FROM FoodMenu WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'event', id, title, deleted_at
+        FROM events WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'schedule_image', date, date, deleted_at
+        FROM DailyScheduleImages WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'lost_and_found', CAST(id AS TEXT), item_name, deleted_at
+        FROM LostAndFound WHERE deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'store_item', CAST(ID AS TEXT), Product_Name, deleted_at
+        FROM School_Store WHERE deleted_at IS NOT NULL;
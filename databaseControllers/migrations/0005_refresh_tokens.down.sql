@@ -0,0 +1,9 @@
+-- Refresh tokens cannot be used as the old login tokens, so everyone is
+-- logged out again.
+DROP TABLE IF EXISTS LoginTokens;
+CREATE TABLE LoginTokens (
+    token    TEXT     PRIMARY KEY,
+    user_id  INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    added_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_user_id ON LoginTokens (user_id, added_at);
@@ -0,0 +1,18 @@
+-- LoginTokens now holds the refresh tokens. Each login starts a family, the
+-- session, and every refresh replaces its token with a new one of the same
+-- family; used tokens are kept until they expire so that presenting one
+-- again is noticed. The random tokens issued before are not refresh tokens
+-- and are dropped, which logs everyone out once.
+
+DROP TABLE IF EXISTS LoginTokens;
+CREATE TABLE LoginTokens (
+    token      TEXT     PRIMARY KEY,
+    family     TEXT     NOT NULL,
+    user_id    INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    added_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    used_at    DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_user_id ON LoginTokens (user_id, added_at);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_family ON LoginTokens (family);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_expires_at ON LoginTokens (expires_at);
@@ -0,0 +1,17 @@
+-- Sessions describes each login, the family of its refresh tokens, so users
+-- can see where they are logged in and end sessions. last_used_at and ip are
+-- those of the last login or refresh.
+
+CREATE TABLE IF NOT EXISTS Sessions (
+    id           TEXT     PRIMARY KEY,
+    user_id      INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    device_name  TEXT     NOT NULL DEFAULT '',
+    ip           TEXT     NOT NULL DEFAULT '',
+    created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON Sessions (user_id, last_used_at);
+
+-- The sessions started before are listed without a device or address.
+INSERT INTO Sessions (id, user_id, created_at, last_used_at)
+SELECT family, user_id, MIN(added_at), MAX(added_at) FROM LoginTokens GROUP BY family;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_sessions_created_at;
+ALTER TABLE Sessions DROP COLUMN user_agent;
@@ -0,0 +1,4 @@
+-- The User-Agent a session logged in with, kept apart from the device name
+-- the client gives.
+ALTER TABLE Sessions ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_sessions_created_at ON Sessions (user_id, created_at);
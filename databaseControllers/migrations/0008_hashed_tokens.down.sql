@@ -0,0 +1,15 @@
+-- The digests cannot be turned back into tokens, so everyone is logged out
+-- again.
+DROP TABLE IF EXISTS LoginTokens;
+CREATE TABLE LoginTokens (
+    token      TEXT     PRIMARY KEY,
+    family     TEXT     NOT NULL,
+    user_id    INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    added_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    used_at    DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_user_id ON LoginTokens (user_id, added_at);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_family ON LoginTokens (family);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_expires_at ON LoginTokens (expires_at);
+DELETE FROM Sessions;
@@ -0,0 +1,20 @@
+-- LoginTokens keeps only the SHA-256 digest of each refresh token, hex
+-- encoded, so a copy of the database does not let anyone log in, and the
+-- first characters of the token so that sessions can still be told apart.
+-- The plaintext tokens stored before are dropped with their sessions, which
+-- logs everyone out once.
+
+DROP TABLE IF EXISTS LoginTokens;
+CREATE TABLE LoginTokens (
+    token_hash TEXT     PRIMARY KEY,
+    prefix     TEXT     NOT NULL,
+    family     TEXT     NOT NULL,
+    user_id    INTEGER  NOT NULL REFERENCES Users (id) ON DELETE CASCADE,
+    added_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    used_at    DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_user_id ON LoginTokens (user_id, added_at);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_family ON LoginTokens (family);
+CREATE INDEX IF NOT EXISTS idx_login_tokens_expires_at ON LoginTokens (expires_at);
+DELETE FROM Sessions;
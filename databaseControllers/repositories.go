@@ -0,0 +1,241 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"errors"
+	"server/databaseTypes"
+	"server/listQuery"
+	"server/restTypes"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// FoodMenuRepo stores the daily food menus.
+type FoodMenuRepo interface {
+	GetByDate(date string) (*databaseTypes.FoodMenu, error)
+	// List returns the page of menus q selects and how many match its filters.
+	List(q listQuery.Query) ([]databaseTypes.FoodMenu, int, error)
+	Create(menu databaseTypes.FoodMenu) error
+	Update(date string, menu databaseTypes.FoodMenu) error
+	Delete(date string) error
+	// UpsertAll inserts or replaces the menus by date in one transaction and
+	// reports what happened to each. With dryRun the transaction is rolled back.
+	// A menu for a date in the trash fails it all with ErrTrashed.
+	UpsertAll(menus []databaseTypes.FoodMenu, dryRun bool) ([]MenuChange, error)
+}
+
+// MenuChange is what FoodMenuRepo.UpsertAll did with one menu.
+type MenuChange string
+
+const (
+	MenuInserted  MenuChange = "inserted"
+	MenuUpdated   MenuChange = "updated"
+	MenuUnchanged MenuChange = "unchanged"
+)
+
+// EventRepo stores the daily schedule events.
+type EventRepo interface {
+	ListByDate(date string) ([]restTypes.Event, error)
+	// Get returns the event with the ID that starts on the date.
+	Get(id string, date string) (*restTypes.Event, error)
+	Create(event restTypes.Event) error
+	Update(event restTypes.Event) error
+	Delete(id string, date string) error
+}
+
+// Image is an image stored with a row and when the row last changed.
+type Image struct {
+	Data      []byte
+	UpdatedAt time.Time
+}
+
+// ScheduleImageRepo stores the daily schedule images, one per date.
+type ScheduleImageRepo interface {
+	GetImage(date string) (*Image, error)
+	SaveImage(date string, image []byte) error
+	DeleteImage(date string) error
+}
+
+// LostAndFoundUpdate holds the fields of a lost and found item to change.
+// Invalid fields and a nil ImageFile keep their current values.
+type LostAndFoundUpdate struct {
+	ItemName      sql.NullString
+	Description   sql.NullString
+	DateFound     sql.NullString
+	LocationFound sql.NullString
+	Status        sql.NullString
+	ImageFile     []byte
+}
+
+// LostAndFoundRepo stores the lost and found items.
+type LostAndFoundRepo interface {
+	// List returns the page of items q selects and how many match its filters.
+	List(q listQuery.Query) ([]databaseTypes.LostAndFound, int, error)
+	// Get returns the item without its image.
+	Get(id int) (*databaseTypes.LostAndFound, error)
+	GetImage(id int) (*Image, error)
+	Create(item restTypes.LostAndFoundInput, image []byte, submitterID int) (int64, error)
+	Update(id int, update LostAndFoundUpdate) error
+	Delete(id int) error
+}
+
+// SchoolStoreUpdate holds the fields of a school store product to change.
+// Invalid fields and a nil ImageFile keep their current values.
+type SchoolStoreUpdate struct {
+	ProductName sql.NullString
+	Category    sql.NullString
+	Price       sql.NullFloat64
+	Stock       sql.NullInt64
+	Description sql.NullString
+	ImageFile   []byte
+}
+
+// StoreRepo stores the school store products.
+type StoreRepo interface {
+	// List returns the page of products q selects and how many match its filters.
+	List(q listQuery.Query) ([]databaseTypes.SchoolStore, int, error)
+	// Get returns the product without its image.
+	Get(id int) (*databaseTypes.SchoolStore, error)
+	GetImage(id int) (*Image, error)
+	Create(item databaseTypes.SchoolStore) (int64, error)
+	Update(id int, update SchoolStoreUpdate) error
+	Delete(id int) error
+}
+
+// SportsRepo reads the sports teams and their games.
+type SportsRepo interface {
+	// ListInfo and ListGames return the page q selects and how many rows
+	// match its filters.
+	ListInfo(q listQuery.Query) ([]databaseTypes.SportsInfo, int, error)
+	ListGames(q listQuery.Query) ([]databaseTypes.SportsGame, int, error)
+}
+
+// UserRepo stores the user accounts.
+type UserRepo interface {
+	// GetByEmail returns the user with the email, ignoring case.
+	GetByEmail(email string) (*databaseTypes.User, error)
+	// GetByID returns the user without their password hash.
+	GetByID(id int) (*databaseTypes.User, error)
+	ListByType(userType int) ([]databaseTypes.User, error)
+	// Create adds the user, whose Password must already be hashed.
+	Create(user databaseTypes.User) (int64, error)
+	UpdateName(id int, firstName, lastName string) error
+	// Import creates the added users, whose Password must already be hashed,
+	// and changes the names of the renamed ones by ID, all in one
+	// transaction.
+	Import(added, renamed []databaseTypes.User) error
+}
+
+// TokenRepo stores the login sessions and their refresh tokens.
+type TokenRepo interface {
+	// CreateSession stores a new session with its first refresh token.
+	CreateSession(session databaseTypes.Session, token databaseTypes.LoginToken) error
+	// Insert stores the next refresh token of a session and records its use
+	// from ip.
+	Insert(token databaseTypes.LoginToken, ip string) error
+	// Use marks the token used and returns it. It returns ErrNotFound for an
+	// unknown or expired token, and the token with ErrTokenReused if it was
+	// already used.
+	Use(token string) (*databaseTypes.LoginToken, error)
+	// Find returns the token, used or not, without using it. It returns
+	// ErrNotFound for an unknown or expired token.
+	Find(token string) (*databaseTypes.LoginToken, error)
+	// HasSession reports whether the session exists, that is has not been ended.
+	HasSession(id string) (bool, error)
+	// ListSessions returns the user's sessions that can still be refreshed,
+	// most recently used first.
+	ListSessions(userID int) ([]databaseTypes.Session, error)
+	// DeleteSession ends the user's session and deletes its tokens, or
+	// returns ErrNotFound if the user has no such session.
+	DeleteSession(userID int, id string) error
+	// DeleteSessions ends every session of the user and returns their IDs.
+	DeleteSessions(userID int) ([]string, error)
+	// EvictSessions ends the user's sessions but the keep most recently
+	// started and returns the IDs of those ended.
+	EvictSessions(userID int, keep int) ([]string, error)
+	// DeleteExpired deletes the expired tokens and the sessions left without any.
+	DeleteExpired() error
+	// CountActive counts the sessions holding an unused, unexpired token.
+	CountActive() (int, error)
+}
+
+// AuditRepo stores the audit log of changes made through the API.
+type AuditRepo interface {
+	Record(entry databaseTypes.AuditEntry) error
+	// List returns the page of entries q selects and how many match its filters.
+	List(q listQuery.Query) ([]databaseTypes.AuditEntry, int, error)
+}
+
+// TrashRepo lists, restores and purges the rows moved to the trash by the
+// Delete methods of the other repositories.
+type TrashRepo interface {
+	// List returns the page of trashed entities q selects and how many match its filters.
+	List(q listQuery.Query) ([]databaseTypes.TrashItem, int, error)
+	// Restore takes the entity out of the trash and returns it as it was
+	// listed there, or returns ErrNotFound if it is not there.
+	Restore(entityType, id string) (*databaseTypes.TrashItem, error)
+	// Delete deletes the entity in the trash for good and returns it as it
+	// was listed there, or returns ErrNotFound if it is not there.
+	Delete(entityType, id string) (*databaseTypes.TrashItem, error)
+	// Purge deletes for good everything moved to the trash before the time
+	// and returns how many rows it deleted.
+	Purge(before time.Time) (int64, error)
+}
+
+// Repositories groups every repository backed by one database pool.
+type Repositories struct {
+	DB             *sql.DB
+	FoodMenus      FoodMenuRepo
+	Events         EventRepo
+	ScheduleImages ScheduleImageRepo
+	LostAndFound   LostAndFoundRepo
+	Store          StoreRepo
+	Sports         SportsRepo
+	Users          UserRepo
+	Tokens         TokenRepo
+	Audit          AuditRepo
+	Trash          TrashRepo
+}
+
+// NewRepositories builds the SQLite implementation of every repository on top of db.
+// Statements made through them are timed for the metrics.
+func NewRepositories(db *sql.DB) *Repositories {
+	timed := &timedDB{DB: db}
+	return &Repositories{
+		DB:             db,
+		FoodMenus:      &foodMenuRepo{db: timed},
+		Events:         &eventRepo{db: timed},
+		ScheduleImages: &scheduleImageRepo{db: timed},
+		LostAndFound:   &lostAndFoundRepo{db: timed},
+		Store:          &storeRepo{db: timed},
+		Sports:         &sportsRepo{db: timed},
+		Users:          &userRepo{db: timed},
+		Tokens:         &tokenRepo{db: timed},
+		Audit:          &auditRepo{db: timed},
+		Trash:          &trashRepo{db: timed},
+	}
+}
+
+// rowsAffectedOrNotFound turns an update or delete that matched no rows into ErrNotFound.
+func rowsAffectedOrNotFound(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// conflictOr turns a write that broke a UNIQUE or PRIMARY KEY constraint into
+// ErrConflict and returns any other error as is.
+func conflictOr(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey) {
+		return ErrConflict
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"server/databaseTypes"
+	"server/listQuery"
+)
+
+type storeRepo struct {
+	db *timedDB
+}
+
+// StoreListing is how the school store list can be sorted and filtered.
+var StoreListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"id":           "ID",
+		"product_name": "Product_Name",
+		"price":        "Price",
+		"category":     "Category",
+		"date_added":   "Date_Added",
+	},
+	DefaultSort: "product_name",
+	Tiebreak:    "ID",
+	Filters: map[string]listQuery.Filter{
+		"category":  {Expr: "Category", Type: "int"},
+		"price_min": {Expr: "Price", Op: ">=", Type: "number"},
+		"price_max": {Expr: "Price", Op: "<=", Type: "number"},
+	},
+	Scope: "deleted_at IS NULL",
+}
+
+func (r *storeRepo) List(q listQuery.Query) ([]databaseTypes.SchoolStore, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM School_Store"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT Product_Name, Description, Price, Category, ID FROM School_Store"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []databaseTypes.SchoolStore{}
+	for rows.Next() {
+		var item databaseTypes.SchoolStore
+		if err := rows.Scan(&item.ProductName, &item.Description, &item.Price, &item.Category, &item.ID); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *storeRepo) Get(id int) (*databaseTypes.SchoolStore, error) {
+	var item databaseTypes.SchoolStore
+	err := r.db.QueryRow("SELECT ID, Product_Name, Category, Price, Stock, Description, Date_Added FROM School_Store WHERE ID = ? AND deleted_at IS NULL", id).
+		Scan(&item.ID, &item.ProductName, &item.Category, &item.Price, &item.Stock, &item.Description, &item.DateAdded)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *storeRepo) GetImage(id int) (*Image, error) {
+	var image Image
+	err := r.db.QueryRow("SELECT image_file, updated_at FROM School_Store WHERE ID = ? AND deleted_at IS NULL", id).Scan(&image.Data, &image.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *storeRepo) Create(item databaseTypes.SchoolStore) (int64, error) {
+	res, err := r.db.Exec("INSERT INTO School_Store (Product_Name, Description, Price, Category, image_file, Date_Added, Stock) VALUES (?, ?, ?, ?, ?, DATE('now'), ?)",
+		item.ProductName, item.Description, item.Price, item.Category, item.ImageFile, item.Stock)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *storeRepo) Update(id int, update SchoolStoreUpdate) error {
+	res, err := r.db.Exec("UPDATE School_Store SET Product_Name=COALESCE(?, Product_Name), Category=COALESCE(?, Category), Price=COALESCE(?, Price), Stock=COALESCE(?, Stock), Description=COALESCE(?, Description), image_file=COALESCE(?, image_file) WHERE ID=? AND deleted_at IS NULL",
+		update.ProductName, update.Category, update.Price, update.Stock, update.Description, update.ImageFile, id)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+// Delete moves the product to the trash.
+func (r *storeRepo) Delete(id int) error {
+	res, err := r.db.Exec("UPDATE School_Store SET deleted_at = CURRENT_TIMESTAMP WHERE ID = ? AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
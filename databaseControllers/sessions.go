@@ -0,0 +1,232 @@
+package databaseControllers
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"server/databaseTypes"
+)
+
+type tokenRepo struct {
+	db *timedDB
+}
+
+// tokenPrefixLength is how much of a token is kept to tell sessions apart.
+const tokenPrefixLength = 8
+
+// hashToken returns the digest a token is stored and looked up by. Refresh
+// tokens are random, so a plain SHA-256 cannot be reversed by guessing.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenPrefix returns the start of the token kept in plaintext.
+func tokenPrefix(token string) string {
+	if len(token) > tokenPrefixLength {
+		return token[:tokenPrefixLength]
+	}
+	return token
+}
+
+func (r *tokenRepo) CreateSession(session databaseTypes.Session, token databaseTypes.LoginToken) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit is a no-op
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("INSERT INTO Sessions (id, user_id, device_name, user_agent, ip) VALUES (?, ?, ?, ?, ?)",
+		session.ID, session.UserID, session.DeviceName, session.UserAgent, session.IP); err != nil {
+		return err
+	}
+	if err := insertToken(tx, token); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *tokenRepo) Insert(token databaseTypes.LoginToken, ip string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertToken(tx, token); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE Sessions SET last_used_at = CURRENT_TIMESTAMP, ip = ? WHERE id = ?", ip, token.Family); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertToken(db execer, token databaseTypes.LoginToken) error {
+	_, err := db.Exec("INSERT INTO LoginTokens (token_hash, prefix, family, user_id, expires_at) VALUES (?, ?, ?, ?, ?)",
+		hashToken(token.Token), tokenPrefix(token.Token), token.Family, token.UserID, token.ExpiresAt.UTC().Format("2006-01-02 15:04:05"))
+	return err
+}
+
+func (r *tokenRepo) Use(token string) (*databaseTypes.LoginToken, error) {
+	hash := hashToken(token)
+	// Only one of two requests racing with the same token can mark it used
+	res, err := r.db.Exec("UPDATE LoginTokens SET used_at = CURRENT_TIMESTAMP WHERE token_hash = ? AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP", hash)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	t := databaseTypes.LoginToken{Token: token}
+	var expired bool
+	err = r.db.QueryRow("SELECT family, user_id, expires_at, expires_at <= CURRENT_TIMESTAMP FROM LoginTokens WHERE token_hash = ?", hash).
+		Scan(&t.Family, &t.UserID, &t.ExpiresAt, &expired)
+	if err == sql.ErrNoRows || (err == nil && expired) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return &t, ErrTokenReused
+	}
+	return &t, nil
+}
+
+func (r *tokenRepo) Find(token string) (*databaseTypes.LoginToken, error) {
+	t := databaseTypes.LoginToken{Token: token}
+	err := r.db.QueryRow("SELECT family, user_id, expires_at FROM LoginTokens WHERE token_hash = ? AND expires_at > CURRENT_TIMESTAMP", hashToken(token)).
+		Scan(&t.Family, &t.UserID, &t.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *tokenRepo) HasSession(id string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS (SELECT 1 FROM Sessions WHERE id = ?)", id).Scan(&exists)
+	return exists, err
+}
+
+func (r *tokenRepo) ListSessions(userID int) ([]databaseTypes.Session, error) {
+	// The token of a live session is its one unused, unexpired token
+	rows, err := r.db.Query("SELECT Sessions.id, Sessions.user_id, device_name, user_agent, ip, created_at, last_used_at, LoginTokens.prefix FROM Sessions JOIN LoginTokens ON LoginTokens.family = Sessions.id AND LoginTokens.used_at IS NULL AND LoginTokens.expires_at > CURRENT_TIMESTAMP WHERE Sessions.user_id = ? ORDER BY last_used_at DESC, Sessions.id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []databaseTypes.Session{}
+	for rows.Next() {
+		var session databaseTypes.Session
+		if err := rows.Scan(&session.ID, &session.UserID, &session.DeviceName, &session.UserAgent, &session.IP, &session.CreatedAt, &session.LastUsedAt, &session.TokenPrefix); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *tokenRepo) DeleteSession(userID int, id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("DELETE FROM Sessions WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return err
+	}
+	if err := rowsAffectedOrNotFound(res); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM LoginTokens WHERE family = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *tokenRepo) DeleteSessions(userID int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids, err := sessionIDs(tx, "SELECT id FROM Sessions WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("DELETE FROM Sessions WHERE user_id = ?", userID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("DELETE FROM LoginTokens WHERE user_id = ?", userID); err != nil {
+		return nil, err
+	}
+	return ids, tx.Commit()
+}
+
+func (r *tokenRepo) EvictSessions(userID int, keep int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// LIMIT -1 is SQLite for no limit, leaving the OFFSET to skip the newest
+	ids, err := sessionIDs(tx, "SELECT id FROM Sessions WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT -1 OFFSET ?", userID, keep)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if _, err := tx.Exec("DELETE FROM Sessions WHERE id = ?", id); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec("DELETE FROM LoginTokens WHERE family = ?", id); err != nil {
+			return nil, err
+		}
+	}
+	return ids, tx.Commit()
+}
+
+// sessionIDs returns the IDs selected by query.
+func sessionIDs(tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *tokenRepo) DeleteExpired() error {
+	if _, err := r.db.Exec("DELETE FROM LoginTokens WHERE expires_at <= CURRENT_TIMESTAMP"); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("DELETE FROM Sessions WHERE id NOT IN (SELECT family FROM LoginTokens)")
+	return err
+}
+
+func (r *tokenRepo) CountActive() (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(DISTINCT family) FROM LoginTokens WHERE used_at IS NULL AND expires_at > CURRENT_TIMESTAMP").Scan(&count)
+	return count, err
+}
@@ -0,0 +1,136 @@
+package databaseControllers
+
+import (
+	"server/databaseTypes"
+	"testing"
+	"time"
+)
+
+// newTestUser adds a user to the repositories and returns its ID.
+func newTestUser(t *testing.T, repos *Repositories, email string) int {
+	t.Helper()
+	id, err := repos.Users.Create(databaseTypes.User{UserType: 1, FirstName: "Test", LastName: "User", Email: email, Password: "hash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int(id)
+}
+
+// startSession stores a session of the user whose first refresh token is token.
+func startSession(t *testing.T, repos *Repositories, userID int, id, token string) {
+	t.Helper()
+	err := repos.Tokens.CreateSession(databaseTypes.Session{ID: id, UserID: userID, DeviceName: "Pixel 8", IP: "203.0.113.7"},
+		databaseTypes.LoginToken{Token: token, Family: id, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTokenRotation(t *testing.T) {
+	repos := newTestRepos(t)
+	userID := newTestUser(t, repos, "a@example.com")
+	startSession(t, repos, userID, "s1", "first-token")
+
+	found, err := repos.Tokens.Find("first-token")
+	if err != nil || found.Family != "s1" || found.UserID != userID {
+		t.Fatalf("Find = %+v, %v", found, err)
+	}
+	used, err := repos.Tokens.Use("first-token")
+	if err != nil || used.Family != "s1" {
+		t.Fatalf("Use = %+v, %v", used, err)
+	}
+	next := databaseTypes.LoginToken{Token: "second-token", Family: "s1", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := repos.Tokens.Insert(next, "198.51.100.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Using a token twice is reported with the token, so its session can be ended
+	reused, err := repos.Tokens.Use("first-token")
+	if err != ErrTokenReused || reused == nil || reused.Family != "s1" {
+		t.Fatalf("second Use = %+v, %v, want the token and ErrTokenReused", reused, err)
+	}
+	if _, err := repos.Tokens.Use("unknown-token"); err != ErrNotFound {
+		t.Fatalf("Use of an unknown token = %v, want ErrNotFound", err)
+	}
+
+	sessions, err := repos.Tokens.ListSessions(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("ListSessions returned %d sessions, want 1", len(sessions))
+	}
+	if s := sessions[0]; s.ID != "s1" || s.IP != "198.51.100.1" || s.DeviceName != "Pixel 8" || s.TokenPrefix != "second-t" {
+		t.Errorf("ListSessions = %+v", s)
+	}
+	if n, err := repos.Tokens.CountActive(); err != nil || n != 1 {
+		t.Errorf("CountActive = %d, %v, want 1", n, err)
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	repos := newTestRepos(t)
+	userID := newTestUser(t, repos, "a@example.com")
+	err := repos.Tokens.CreateSession(databaseTypes.Session{ID: "s1", UserID: userID},
+		databaseTypes.LoginToken{Token: "old-token", Family: "s1", UserID: userID, ExpiresAt: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repos.Tokens.Find("old-token"); err != ErrNotFound {
+		t.Errorf("Find of an expired token = %v, want ErrNotFound", err)
+	}
+	if _, err := repos.Tokens.Use("old-token"); err != ErrNotFound {
+		t.Errorf("Use of an expired token = %v, want ErrNotFound", err)
+	}
+	if err := repos.Tokens.DeleteExpired(); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := repos.Tokens.HasSession("s1"); err != nil || exists {
+		t.Errorf("HasSession = %v, %v after DeleteExpired, want false", exists, err)
+	}
+}
+
+func TestDeleteSessions(t *testing.T) {
+	repos := newTestRepos(t)
+	userID := newTestUser(t, repos, "a@example.com")
+	otherID := newTestUser(t, repos, "b@example.com")
+	startSession(t, repos, userID, "s1", "token-1")
+	startSession(t, repos, userID, "s2", "token-2")
+	startSession(t, repos, userID, "s3", "token-3")
+	startSession(t, repos, otherID, "s4", "token-4")
+
+	// Users can only end their own sessions
+	if err := repos.Tokens.DeleteSession(otherID, "s1"); err != ErrNotFound {
+		t.Fatalf("DeleteSession of another user's session = %v, want ErrNotFound", err)
+	}
+	if err := repos.Tokens.DeleteSession(userID, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := repos.Tokens.HasSession("s1"); exists {
+		t.Error("s1 still exists after DeleteSession")
+	}
+	if _, err := repos.Tokens.Find("token-1"); err != ErrNotFound {
+		t.Errorf("Find of the token of a deleted session = %v, want ErrNotFound", err)
+	}
+
+	// s3 is the newest session, or as new as s2 with a greater ID
+	evicted, err := repos.Tokens.EvictSessions(userID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evicted) != 1 || evicted[0] != "s2" {
+		t.Errorf("EvictSessions = %v, want [s2]", evicted)
+	}
+
+	ended, err := repos.Tokens.DeleteSessions(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ended) != 1 || ended[0] != "s3" {
+		t.Errorf("DeleteSessions = %v, want [s3]", ended)
+	}
+	if exists, _ := repos.Tokens.HasSession("s4"); !exists {
+		t.Error("DeleteSessions ended the session of another user")
+	}
+}
@@ -0,0 +1,118 @@
+package databaseControllers
+
+import (
+	"fmt"
+	"server/databaseTypes"
+	"server/listQuery"
+	"time"
+)
+
+// gameScheduleLayout is the format game_schedule is stored in.
+const gameScheduleLayout = "2006-01-02 03:04 PM"
+
+// gameScheduleSortable is game_schedule rewritten as "2006-01-02 15:04", which
+// sorts in time order.
+const gameScheduleSortable = "substr(game_schedule, 1, 11) || " +
+	"CASE WHEN substr(game_schedule, 18, 2) = 'PM' AND substr(game_schedule, 12, 2) <> '12' THEN printf('%02d', substr(game_schedule, 12, 2) + 12) " +
+	"WHEN substr(game_schedule, 18, 2) = 'AM' AND substr(game_schedule, 12, 2) = '12' THEN '00' " +
+	"ELSE substr(game_schedule, 12, 2) END || substr(game_schedule, 14, 3)"
+
+// SportsInfoListing is how the sports team list can be sorted and filtered.
+var SportsInfoListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"id":         "id",
+		"sport_name": "sport_name",
+		"category":   "category",
+		"season":     "season",
+	},
+	DefaultSort: "sport_name",
+	Tiebreak:    "id",
+	Filters: map[string]listQuery.Filter{
+		"sport_name": {Expr: "sport_name"},
+		"category":   {Expr: "category", Type: "int"},
+		"season":     {Expr: "season", Type: "int"},
+	},
+}
+
+// SportsGameListing is how the sports game list can be sorted and filtered.
+var SportsGameListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"id":            "id",
+		"sport_name":    "sport_name",
+		"category":      "category",
+		"game_schedule": gameScheduleSortable,
+	},
+	DefaultSort: "game_schedule",
+	Tiebreak:    "id",
+	Filters: map[string]listQuery.Filter{
+		"sport_name":   {Expr: "sport_name"},
+		"category":     {Expr: "category", Type: "int"},
+		"home_or_away": {Expr: "home_or_away", Type: "int"},
+		"date_from":    {Expr: "substr(game_schedule, 1, 10)", Op: ">=", Type: "date"},
+		"date_to":      {Expr: "substr(game_schedule, 1, 10)", Op: "<=", Type: "date"},
+	},
+}
+
+type sportsRepo struct {
+	db *timedDB
+}
+
+func (r *sportsRepo) ListInfo(q listQuery.Query) ([]databaseTypes.SportsInfo, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM SportsInfo"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT id, sport_name, category, season, coach_name, coach_contact, roster FROM SportsInfo"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []databaseTypes.SportsInfo{}
+	for rows.Next() {
+		var info databaseTypes.SportsInfo
+		err := rows.Scan(&info.ID, &info.SportName, &info.Category, &info.Season,
+			&info.CoachName, &info.CoachContact, &info.Roster)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, info)
+	}
+	return list, total, rows.Err()
+}
+
+func (r *sportsRepo) ListGames(q listQuery.Query) ([]databaseTypes.SportsGame, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM SportsGames"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	rows, err := r.db.Query("SELECT id, sport_name, category, game_location, opponent_school, home_or_away, match_result, coach_comment, strftime(game_schedule) FROM SportsGames"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []databaseTypes.SportsGame{}
+	for rows.Next() {
+		var game databaseTypes.SportsGame
+		var gameSchedule string
+		err := rows.Scan(&game.ID, &game.SportName, &game.Category, &game.GameLocation,
+			&game.OpponentSchool, &game.HomeOrAway, &game.MatchResult, &game.CoachComment,
+			&gameSchedule)
+		if err != nil {
+			return nil, 0, err
+		}
+		game.GameSchedule, err = time.Parse(gameScheduleLayout, gameSchedule)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parsing schedule of game %d: %w", game.ID, err)
+		}
+		list = append(list, game)
+	}
+	return list, total, rows.Err()
+}
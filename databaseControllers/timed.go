@@ -0,0 +1,77 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"server/metrics"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timedDB is the pool the repositories query through. It records how long
+// each statement takes, labelled by statement kind and table.
+type timedDB struct {
+	*sql.DB
+}
+
+func (db *timedDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer observeQuery(query, time.Now())
+	return db.DB.Exec(query, args...)
+}
+
+func (db *timedDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer observeQuery(query, time.Now())
+	return db.DB.Query(query, args...)
+}
+
+func (db *timedDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	defer observeQuery(query, time.Now())
+	return db.DB.QueryRow(query, args...)
+}
+
+type queryLabels struct {
+	statement, table string
+}
+
+// queryLabelCache maps each query string, of which there is a fixed set, to its labels.
+var queryLabelCache sync.Map
+
+func observeQuery(query string, start time.Time) {
+	labels, ok := queryLabelCache.Load(query)
+	if !ok {
+		labels, _ = queryLabelCache.LoadOrStore(query, labelQuery(query))
+	}
+	l := labels.(queryLabels)
+	metrics.DBQueryDuration.Observe(time.Since(start).Seconds(), l.statement, l.table)
+}
+
+// labelQuery finds the statement kind and the main table of a query, such as
+// "select" and "FoodMenu" for "SELECT date FROM FoodMenu WHERE ...".
+func labelQuery(query string) queryLabels {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return queryLabels{statement: "other", table: "unknown"}
+	}
+	labels := queryLabels{statement: strings.ToLower(fields[0]), table: "unknown"}
+	after := func(keyword string) {
+		for i := 0; i+1 < len(fields); i++ {
+			if strings.EqualFold(fields[i], keyword) {
+				labels.table = strings.Trim(fields[i+1], "`\"();")
+				return
+			}
+		}
+	}
+	switch labels.statement {
+	case "select", "delete":
+		after("FROM")
+	case "insert":
+		after("INTO")
+	case "update":
+		if len(fields) > 1 {
+			labels.table = fields[1]
+		}
+	default:
+		labels.statement = "other"
+	}
+	return labels
+}
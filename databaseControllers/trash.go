@@ -0,0 +1,160 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"fmt"
+	"server/databaseTypes"
+	"server/listQuery"
+	"time"
+)
+
+type trashRepo struct {
+	db *timedDB
+}
+
+// trashTable is where the rows of one entity type are stored.
+type trashTable struct {
+	table string
+	// key is the column the entity ID is stored in.
+	key string
+}
+
+// trashTables maps the entity types of the Trash view, which are those of
+// the audit log, to their tables.
+var trashTables = map[string]trashTable{
+	"food_menu":      {table: "FoodMenu", key: "date"},
+	"event":          {table: "events", key: "id"},
+	"schedule_image": {table: "DailyScheduleImages", key: "date"},
+	"lost_and_found": {table: "LostAndFound", key: "id"},
+	"store_item":     {table: "School_Store", key: "ID"},
+}
+
+// TrashListing is how the trash can be sorted and filtered.
+var TrashListing = listQuery.Spec{
+	Sorts: map[string]string{
+		"deleted_at":  "deleted_at",
+		"entity_type": "entity_type",
+		"name":        "name",
+	},
+	DefaultSort: "-deleted_at",
+	Tiebreak:    "entity_type, entity_id",
+	Filters: map[string]listQuery.Filter{
+		"entity_type": {Expr: "entity_type"},
+		"from":        {Expr: "deleted_at", Op: ">=", Type: "datetime"},
+		"to":          {Expr: "deleted_at", Op: "<", Type: "datetime"},
+	},
+}
+
+func (r *trashRepo) List(q listQuery.Query) ([]databaseTypes.TrashItem, int, error) {
+	where, args := q.Where()
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM Trash"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, args := q.OrderBy(args)
+	// The view loses the column types, so deleted_at is read as text
+	rows, err := r.db.Query("SELECT entity_type, entity_id, name, strftime('%Y-%m-%dT%H:%M:%SZ', deleted_at) FROM Trash"+where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []databaseTypes.TrashItem{}
+	for rows.Next() {
+		var item databaseTypes.TrashItem
+		var deletedAt string
+		if err := rows.Scan(&item.EntityType, &item.EntityID, &item.Name, &deletedAt); err != nil {
+			return nil, 0, err
+		}
+		if item.DeletedAt, err = time.Parse(time.RFC3339, deletedAt); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+func (r *trashRepo) Restore(entityType, id string) (*databaseTypes.TrashItem, error) {
+	return r.takeOut(entityType, id, "UPDATE %s SET deleted_at = NULL WHERE %s = ? AND deleted_at IS NOT NULL")
+}
+
+func (r *trashRepo) Delete(entityType, id string) (*databaseTypes.TrashItem, error) {
+	return r.takeOut(entityType, id, "DELETE FROM %s WHERE %s = ? AND deleted_at IS NOT NULL")
+}
+
+// takeOut runs statement, formatted with the table and key column of the
+// entity type, on the entity in the trash and returns the entity as it was
+// listed there, or ErrNotFound if it is not there.
+func (r *trashRepo) takeOut(entityType, id, statement string) (*databaseTypes.TrashItem, error) {
+	t, ok := trashTables[entityType]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// Rolling back after a commit is a no-op
+	defer tx.Rollback()
+
+	item := databaseTypes.TrashItem{EntityType: entityType}
+	var deletedAt string
+	err = tx.QueryRow("SELECT entity_id, name, strftime('%Y-%m-%dT%H:%M:%SZ', deleted_at) FROM Trash WHERE entity_type = ? AND entity_id = ?", entityType, id).
+		Scan(&item.EntityID, &item.Name, &deletedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if item.DeletedAt, err = time.Parse(time.RFC3339, deletedAt); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(fmt.Sprintf(statement, t.table, t.key), id); err != nil {
+		return nil, err
+	}
+	return &item, tx.Commit()
+}
+
+func (r *trashRepo) Purge(before time.Time) (int64, error) {
+	cutoff := before.UTC().Format("2006-01-02 15:04:05")
+	var purged int64
+	for _, t := range trashTables {
+		res, err := r.db.Exec("DELETE FROM "+t.table+" WHERE deleted_at < ?", cutoff)
+		if err != nil {
+			return purged, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return purged, err
+		}
+		purged += n
+	}
+	return purged, nil
+}
+
+// execer runs statements on the pool or in a transaction.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// queryer runs queries on the pool or in a transaction.
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// notTrashed returns ErrTrashed if a row in the trash has value in column,
+// which is unique, so a new row cannot take it until the trashed one is
+// restored or purged. table and column are never user input.
+func notTrashed(db queryer, table, column string, value interface{}) error {
+	var trashed bool
+	err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM "+table+" WHERE "+column+" = ? AND deleted_at IS NOT NULL)", value).Scan(&trashed)
+	if err != nil {
+		return err
+	}
+	if trashed {
+		return ErrTrashed
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package databaseControllers
+
+import (
+	"net/url"
+	"server/databaseTypes"
+	"server/listQuery"
+	"testing"
+	"time"
+)
+
+func TestTrashRestore(t *testing.T) {
+	repos := newTestRepos(t)
+	menu := databaseTypes.FoodMenu{Date: "2024-01-01", Breakfast: "[]", Lunch: "[]", Dinner: "[]"}
+	if err := repos.FoodMenus.Create(menu); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.FoodMenus.Delete(menu.Date); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.FoodMenus.GetByDate(menu.Date); err != ErrNotFound {
+		t.Fatalf("GetByDate of a trashed menu = %v, want ErrNotFound", err)
+	}
+
+	q, errs := listQuery.Parse(url.Values{"entity_type": {"food_menu"}}, TrashListing)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	items, total, err := repos.Trash.List(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(items) != 1 || items[0].EntityID != menu.Date {
+		t.Fatalf("Trash.List = %+v, %d, want the menu", items, total)
+	}
+
+	// The date stays taken until the trashed menu is restored or purged
+	if err := repos.FoodMenus.Create(menu); err != ErrTrashed {
+		t.Errorf("Create over a trashed menu = %v, want ErrTrashed", err)
+	}
+	if _, err := repos.Trash.Restore("event", menu.Date); err != ErrNotFound {
+		t.Errorf("Restore of the wrong entity type = %v, want ErrNotFound", err)
+	}
+	restored, err := repos.Trash.Restore("food_menu", menu.Date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.EntityID != menu.Date {
+		t.Errorf("Restore = %+v", restored)
+	}
+	if _, err := repos.FoodMenus.GetByDate(menu.Date); err != nil {
+		t.Errorf("GetByDate of a restored menu = %v", err)
+	}
+	if _, err := repos.Trash.Restore("food_menu", menu.Date); err != ErrNotFound {
+		t.Errorf("second Restore = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTrashDelete(t *testing.T) {
+	repos := newTestRepos(t)
+	menu := databaseTypes.FoodMenu{Date: "2024-01-01", Breakfast: "[]", Lunch: "[]", Dinner: "[]"}
+	if err := repos.FoodMenus.Create(menu); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.Trash.Delete("food_menu", menu.Date); err != ErrNotFound {
+		t.Fatalf("Delete of a menu not in the trash = %v, want ErrNotFound", err)
+	}
+	if err := repos.FoodMenus.Delete(menu.Date); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repos.Trash.Delete("food_menu", menu.Date); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.FoodMenus.Create(menu); err != nil {
+		t.Errorf("Create after the trashed menu was deleted = %v", err)
+	}
+}
+
+func TestTrashPurge(t *testing.T) {
+	repos := newTestRepos(t)
+	for _, date := range []string{"2024-01-01", "2024-01-02"} {
+		if err := repos.FoodMenus.Create(databaseTypes.FoodMenu{Date: date, Breakfast: "[]", Lunch: "[]", Dinner: "[]"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repos.FoodMenus.Delete("2024-01-01"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := repos.Trash.Purge(time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("Purge of what was trashed over an hour ago = %d, %v, want nothing", n, err)
+	}
+	if n, err := repos.Trash.Purge(time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Errorf("Purge = %d, %v, want 1", n, err)
+	}
+	if _, err := repos.FoodMenus.GetByDate("2024-01-02"); err != nil {
+		t.Errorf("Purge took a menu not in the trash: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package databaseControllers
+
+import (
+	"database/sql"
+	"fmt"
+	"server/databaseTypes"
+)
+
+type userRepo struct {
+	db *timedDB
+}
+
+func (r *userRepo) GetByEmail(email string) (*databaseTypes.User, error) {
+	var user databaseTypes.User
+	err := r.db.QueryRow("SELECT id, user_type, first_name, last_name, email, password FROM Users WHERE email = ? COLLATE NOCASE", email).
+		Scan(&user.ID, &user.UserType, &user.FirstName, &user.LastName, &user.Email, &user.Password)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepo) GetByID(id int) (*databaseTypes.User, error) {
+	var user databaseTypes.User
+	err := r.db.QueryRow("SELECT id, user_type, first_name, last_name, email FROM Users WHERE id = ?", id).
+		Scan(&user.ID, &user.UserType, &user.FirstName, &user.LastName, &user.Email)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepo) ListByType(userType int) ([]databaseTypes.User, error) {
+	rows, err := r.db.Query("SELECT id, user_type, first_name, last_name, email FROM Users WHERE user_type = ? ORDER BY email", userType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []databaseTypes.User
+	for rows.Next() {
+		var user databaseTypes.User
+		if err := rows.Scan(&user.ID, &user.UserType, &user.FirstName, &user.LastName, &user.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *userRepo) Create(user databaseTypes.User) (int64, error) {
+	res, err := r.db.Exec("INSERT INTO Users (user_type, first_name, last_name, email, password) VALUES (?, ?, ?, ?, ?)",
+		user.UserType, user.FirstName, user.LastName, user.Email, user.Password)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *userRepo) UpdateName(id int, firstName, lastName string) error {
+	res, err := r.db.Exec("UPDATE Users SET first_name = ?, last_name = ? WHERE id = ?", firstName, lastName, id)
+	if err != nil {
+		return err
+	}
+	return rowsAffectedOrNotFound(res)
+}
+
+func (r *userRepo) Import(added, renamed []databaseTypes.User) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit is a no-op
+	defer tx.Rollback()
+
+	for _, user := range added {
+		if _, err := tx.Exec("INSERT INTO Users (user_type, first_name, last_name, email, password) VALUES (?, ?, ?, ?, ?)",
+			user.UserType, user.FirstName, user.LastName, user.Email, user.Password); err != nil {
+			return fmt.Errorf("adding %s: %w", user.Email, err)
+		}
+	}
+	for _, user := range renamed {
+		if _, err := tx.Exec("UPDATE Users SET first_name = ?, last_name = ? WHERE id = ?", user.FirstName, user.LastName, user.ID); err != nil {
+			return fmt.Errorf("updating %s: %w", user.Email, err)
+		}
+	}
+	return tx.Commit()
+}
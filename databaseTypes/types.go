@@ -1,6 +1,20 @@
 package databaseTypes
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// User types stored in User.UserType.
+const (
+	UserTypeStudent = iota
+	UserTypeParent
+	UserTypeTeacher
+	UserTypeDiningStaff
+	UserTypeCoach
+	UserTypeStoreManager
+	UserTypeAdmin
+)
 
 // User represents a user account.
 type User struct {
@@ -25,12 +39,28 @@ type DailySchedule struct {
 // FoodMenu represents the daily food menu.
 type FoodMenu struct {
 	ID        int    `json:"id" example:"1"`
-	Date      string `json:"date" example:"2022-01-01"`
-	Breakfast string `json:"breakfast" example:"Omelette"`
-	Lunch     string `json:"lunch" example:"Pasta"`
-	Dinner    string `json:"dinner" example:"Grilled chicken"`
+	Date      string `json:"date" example:"2022-01-01" validate:"required,date"`
+	Breakfast string `json:"breakfast" example:"Omelette" validate:"max=10000"`
+	Lunch     string `json:"lunch" example:"Pasta" validate:"max=10000"`
+	Dinner    string `json:"dinner" example:"Grilled chicken" validate:"max=10000"`
+	// UpdatedAt is when the menu last changed; only GetByDate fills it in.
+	UpdatedAt time.Time `json:"-"`
+}
+
+// Dish is one item of a meal. FoodMenu.Breakfast, Lunch and Dinner each hold
+// a JSON encoded array of dishes.
+type Dish struct {
+	Name        string `json:"name" example:"Scrambled Eggs"`
+	Ingredients string `json:"ingredients" example:"Liquid Egg, Oil"`
+	Group       string `json:"group" example:"N/A"`
 }
 
+// Statuses stored in LostAndFound.Status.
+const (
+	LostAndFoundLost = iota
+	LostAndFoundFound
+)
+
 // LostAndFound represents a lost and found item.
 type LostAndFound struct {
 	ID            int       `json:"id" example:"1"`
@@ -80,10 +110,32 @@ type SchoolStore struct {
 	DateAdded   time.Time `json:"Date_Added" example:"2022-01-01T12:00:00Z"`
 }
 
-// LoginToken represents a login token.
+// LoginToken represents a refresh token. The tokens a session was refreshed
+// with share its Family. Token is never stored, only its digest and prefix.
 type LoginToken struct {
-	Token  string `db:"token" json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	UserID int    `db:"user_id" json:"user_id" example:"1"`
+	Token     string    `db:"token" json:"token" example:"3q2-7wVp0tT9lq5yQX3v2fHk1J8cYl4mN6bR0sDxA1E"`
+	Family    string    `db:"family" json:"family" example:"9b2f6c1e-3d4a-4c5b-8e7f-0a1b2c3d4e5f"`
+	UserID    int       `db:"user_id" json:"user_id" example:"1"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at" example:"2026-11-17T08:00:00Z"`
+}
+
+// Session is a login on one device, which lasts as long as it is refreshed.
+type Session struct {
+	ID     string `json:"id" example:"9b2f6c1e-3d4a-4c5b-8e7f-0a1b2c3d4e5f"`
+	UserID int    `json:"user_id" example:"1"`
+	// DeviceName is given by the client at login.
+	DeviceName string `json:"device_name" example:"Pixel 8"`
+	// UserAgent is the User-Agent header of the login.
+	UserAgent string `json:"user_agent" example:"SchoolApp/2.3 (Android 14)"`
+	// IP and LastUsedAt are those of the last login or refresh.
+	IP         string    `json:"ip" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-10-18T08:00:00Z"`
+	LastUsedAt time.Time `json:"last_used_at" example:"2026-10-18T09:45:00Z"`
+	// TokenPrefix is the start of the session's refresh token; only a
+	// digest of the rest is stored.
+	TokenPrefix string `json:"token_prefix" example:"3q2-7wVp"`
+	// Current marks the session of the request listing the sessions.
+	Current bool `json:"current" example:"true"`
 }
 
 // RfidCard represents an RFID card.
@@ -91,3 +143,28 @@ type RfidCard struct {
 	Token  string `db:"token" json:"token" example:"RFID_TOKEN_12345"`
 	UserID int    `db:"user_id" json:"user_id" example:"1"`
 }
+
+// TrashItem is an entity in the trash.
+type TrashItem struct {
+	EntityType string `json:"entity_type" example:"store_item"`
+	EntityID   string `json:"entity_id" example:"12"`
+	// Name is the title, name or date the entity is known by.
+	Name      string    `json:"name" example:"Backpack"`
+	DeletedAt time.Time `json:"deleted_at" example:"2023-05-22T08:30:00Z"`
+}
+
+// AuditEntry records one change made through the API.
+type AuditEntry struct {
+	ID        int       `json:"id" example:"1"`
+	CreatedAt time.Time `json:"created_at" example:"2023-05-22T08:30:00Z"`
+	// UserID is the user who made the change, absent for failed logins.
+	UserID     *int   `json:"user_id,omitempty" example:"2"`
+	Action     string `json:"action" example:"update"`
+	EntityType string `json:"entity_type" example:"food_menu"`
+	EntityID   string `json:"entity_id" example:"2023-05-22"`
+	// Before and After are the entity as JSON, absent when it did not exist.
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	IP        string          `json:"ip" example:"192.0.2.1"`
+	RequestID string          `json:"request_id" example:"3f2a9c1e5b7d4e60"`
+}
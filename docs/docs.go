@@ -18,35 +18,115 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Login to the system and receive an authentication token.",
-                "consumes": [
-                    "application/json"
+        "/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "admin"
+                        ]
+                    }
                 ],
+                "description": "Returns a page of the changes made through the API, newest first: who made each, from which IP and in which request, with the entity before and after. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Authenticate user",
+                "summary": "List the audit log",
                 "parameters": [
                     {
-                        "description": "User login information",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/restTypes.LoginRequest"
-                        }
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Most items to return, 1 to 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "Comma separated fields, - for descending: id, created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes made by this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "purge",
+                            "import",
+                            "backup",
+                            "login",
+                            "login_failed",
+                            "logout",
+                            "revoke_sessions",
+                            "token_reused"
+                        ],
+                        "type": "string",
+                        "description": "Only this action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "food_menu",
+                            "event",
+                            "schedule_image",
+                            "lost_and_found",
+                            "store_item",
+                            "user",
+                            "database"
+                        ],
+                        "type": "string",
+                        "description": "Only changes to this kind of entity",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes to the entity with this ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes at or after this time (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes before this time (YYYY-MM-DD or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
+                            "$ref": "#/definitions/restTypes.AuditLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "401": {
@@ -54,33 +134,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/testToken": {
-            "get": {
+        "/admin/backups": {
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Greets the user with \"Hello, {userName}!\" if he's authorized",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Writes an online backup of the whole database into the server's backup directory and rotates old backups. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Greet the user if he's authorized",
+                "summary": "Take a database backup",
                 "responses": {
-                    "200": {
-                        "description": "Hello, {userName}!",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.BackupResponse"
                         }
                     },
                     "401": {
@@ -89,36 +186,57 @@ const docTemplate = `{
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/import-people": {
             "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Greets the user with \"Hello, {userName}!\" if he's authorized",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Upserts every student and teacher listed in the server's People directory into Users. New accounts get a random initial password that is returned only in this response. Accounts no longer listed are reported as departed, not deleted. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
+                ],
+                "summary": "Import the People directory into the user accounts",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without writing anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Greet the user if he's authorized",
                 "responses": {
                     "200": {
-                        "description": "Hello, {userName}!",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ImportPeopleReport"
                         }
                     },
                     "401": {
@@ -127,8 +245,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
@@ -136,182 +266,293 @@ const docTemplate = `{
                 }
             }
         },
-        "/data/daily-schedule/": {
-            "put": {
+        "/admin/trash": {
+            "get": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Updates the daily schedule event based on the ID and date provided in the JSON",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a page of the deleted food menus, events, schedule images, lost and found items and store products that can still be restored, most recently deleted first. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Admin"
                 ],
+                "summary": "List the trash",
                 "parameters": [
                     {
-                        "description": "Updated Daily Schedule data",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/restTypes.Event"
-                        }
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Most items to return, 1 to 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-deleted_at",
+                        "description": "Comma separated fields, - for descending: deleted_at, entity_type, name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "food_menu",
+                            "event",
+                            "schedule_image",
+                            "lost_and_found",
+                            "store_item"
+                        ],
+                        "type": "string",
+                        "description": "Only this kind of entity",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items deleted at or after this time (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items deleted before this time (YYYY-MM-DD or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
+                            "$ref": "#/definitions/restTypes.TrashResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/trash/{entity_type}/{id}": {
+            "delete": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Uploads the daily schedule event",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes an item in the trash for good, as listed by GET /admin/trash, so a new one may take its key. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Admin"
                 ],
+                "summary": "Purge an item from the trash",
                 "parameters": [
                     {
-                        "description": "Daily Schedule data to update",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/restTypes.Event"
-                        }
+                        "enum": [
+                            "food_menu",
+                            "event",
+                            "schedule_image",
+                            "lost_and_found",
+                            "store_item"
+                        ],
+                        "type": "string",
+                        "description": "Kind of the item",
+                        "name": "entity_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity_id of the item",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/trash/{entity_type}/{id}/restore": {
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Deletes the daily schedule event based on the ID and date provided in the JSON",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Takes a deleted item out of the trash, as listed by GET /admin/trash. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Admin"
                 ],
+                "summary": "Restore an item from the trash",
                 "parameters": [
                     {
-                        "description": "Data to delete an event from the daily schedule",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/restTypes.Event"
-                        }
+                        "enum": [
+                            "food_menu",
+                            "event",
+                            "schedule_image",
+                            "lost_and_found",
+                            "store_item"
+                        ],
+                        "type": "string",
+                        "description": "Kind of the item",
+                        "name": "entity_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity_id of the item",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
+                            "$ref": "#/definitions/restTypes.RestoreResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Not in the trash",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/daily-schedule/events": {
+        "/admin/users/{id}/sessions": {
             "get": {
-                "description": "Retrieves all events for the specified date",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": [
+                            "admin"
+                        ]
+                    }
                 ],
+                "description": "Lists the devices the user is logged in on, most recently used first, each with the first characters of its refresh token. Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Admin"
                 ],
+                "summary": "List the sessions of a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Date of events to retrieve (in the format 'YYYY-MM-DD')",
-                        "name": "date",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -319,149 +560,140 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.GetEventsResponse"
+                            "$ref": "#/definitions/restTypes.SessionsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/data/daily-schedule/image": {
-            "get": {
-                "consumes": [
-                    "*/*"
-                ],
-                "produces": [
-                    "image/*"
-                ],
-                "tags": [
-                    "Event"
-                ],
-                "summary": "Get the image file for the daily schedule of the specified date or the current date",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The date for which to retrieve the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used.",
-                        "name": "date",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "No such user",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "admin"
+                        ]
                     }
                 ],
-                "description": "Uploads the daily schedule image for the provided date to the database",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Logs the user out on every device, e.g. when a phone is lost: their refresh tokens and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default). Administrators only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Admin"
                 ],
+                "summary": "End every session of a user",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "The daily schedule image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The date for which the image is uploaded (format: 2006-01-02)",
-                        "name": "date",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No such user",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Login to the system and receive a short-lived JWT access token and a refresh token to renew it with.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Deletes the daily schedule image for a specific date from the database",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Event"
+                    "Authentication"
                 ],
+                "summary": "Authenticate user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The date for which to delete the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used.",
-                        "name": "date",
-                        "in": "query"
+                        "description": "User login information",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -474,71 +706,76 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/food-menu/": {
-            "get": {
-                "description": "Retrieves the breakfast, lunch, and dinner menu for the current date from the database",
-                "consumes": [
-                    "application/json"
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Ends the session of the access token: its refresh token and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FoodMenu"
+                    "Authentication"
                 ],
-                "summary": "Get the food menu for the current date",
+                "summary": "Log out",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Add a new food menu to the database",
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token works once: presenting one again ends its session, logging out whoever holds it. A session may be refreshed 10 times a minute by default.",
                 "consumes": [
                     "application/json"
                 ],
@@ -546,18 +783,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FoodMenu"
+                    "Authentication"
                 ],
-                "summary": "Add a food menu",
-                "operationId": "addFoodMenu",
+                "summary": "Refresh the access token",
                 "parameters": [
                     {
-                        "description": "Food menu to add",
-                        "name": "foodMenu",
+                        "description": "Refresh token",
+                        "name": "refresh",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                            "$ref": "#/definitions/restTypes.RefreshRequest"
                         }
                     }
                 ],
@@ -571,123 +807,134 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/food-menu/all": {
+        "/auth/sessions": {
             "get": {
-                "description": "Retrieves all the breakfast, lunch, and dinner menus from the database",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Lists the devices you are logged in on, most recently used first. The IP and last use are those of the last login or refresh; current marks the session of this request.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FoodMenu"
+                    "Authentication"
                 ],
+                "summary": "List your sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.AllMenuResponse"
+                            "$ref": "#/definitions/restTypes.SessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/data/food-menu/{date}": {
-            "get": {
-                "description": "Retrieves the breakfast, lunch, and dinner menu for a specific date from the database",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Logs every device out, including this one. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FoodMenu"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The date of the food menu (YYYY-MM-DD)",
-                        "name": "date",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Authentication"
                 ],
+                "summary": "End all your sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/databaseTypes.FoodMenu"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/sessions/{id}": {
             "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a food menu from the database for a given date",
+                "description": "Logs the device of the session out: its refresh token and access tokens stop working. Elsewhere than on the session and admin routes, another server process, or this one after a restart, accepts the access tokens until they expire (15 minutes by default).",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "FoodMenu"
+                    "Authentication"
                 ],
-                "summary": "Delete a food menu",
-                "operationId": "DeleteFoodMenu",
+                "summary": "End one of your sessions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The date of the food menu to delete",
-                        "name": "date",
+                        "description": "Session ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -699,35 +946,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/restTypes.DeleteResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "No such session",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/food-menu/{id}": {
-            "put": {
+        "/auth/testToken": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update the food menu with the specified ID",
+                "description": "Greets the user with \"Hello, {userName}!\" if he's authorized",
                 "consumes": [
                     "application/json"
                 ],
@@ -735,38 +988,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FoodMenu"
-                ],
-                "summary": "Update a food menu",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID of the food menu to update",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New values for the food menu",
-                        "name": "foodMenu",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/databaseTypes.FoodMenu"
-                        }
-                    }
+                    "Authentication"
                 ],
+                "summary": "Greet the user if he's authorized",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/restTypes.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Hello, {userName}!",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "401": {
@@ -775,18 +1004,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/data/games/": {
-            "get": {
-                "description": "Retrieves data about sports games and their results.",
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Greets the user with \"Hello, {userName}!\" if he's authorized",
                 "consumes": [
                     "application/json"
                 ],
@@ -794,35 +1026,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SportsData"
+                    "Authentication"
                 ],
-                "summary": "Get sports game data",
-                "operationId": "get-sports-game-data",
+                "summary": "Greet the user if he's authorized",
                 "responses": {
                     "200": {
-                        "description": "List of sports game data",
+                        "description": "Hello, {userName}!",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.SportsGameDataList"
+                            "type": "string"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/lost-and-found/": {
+        "/data/daily-schedule/": {
             "get": {
-                "description": "Retrieves a list of all lost and found items from the database and returns them as a JSON array in the response body.",
+                "description": "Retrieves all events for the specified date",
                 "consumes": [
                     "application/json"
                 ],
@@ -830,129 +1061,115 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LostAndFound"
+                    "Event"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date of events to retrieve (in the format 'YYYY-MM-DD')",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all lost and found items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/databaseTypes.LostAndFound"
-                            }
+                            "$ref": "#/definitions/restTypes.GetEventsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "schedule:write"
+                        ]
                     }
                 ],
-                "description": "Adds a lost and found item to the database and uploads an image file",
+                "description": "Updates the daily schedule event based on the ID and date provided in the JSON",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "LostAndFound"
+                    "Event"
                 ],
-                "summary": "Add a lost and found item",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Name of the lost/found item",
-                        "name": "item_name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Description of the lost/found item",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Date the item was found",
-                        "name": "date_found",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Location where the item was found",
-                        "name": "location_found",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status of the item (lost or found)",
-                        "name": "status",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image of the lost/found item",
-                        "name": "image_file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Updated Daily Schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Event"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundPostResponse"
+                            "$ref": "#/definitions/restTypes.StatusResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundErrorResponse"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundErrorResponse"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/data/lost-and-found/image/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "schedule:write"
+                        ]
                     }
                 ],
-                "description": "Update an existing lost and found item in the database with the specified ID",
+                "description": "Uploads the daily schedule event",
                 "consumes": [
                     "application/json"
                 ],
@@ -960,206 +1177,145 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LostAndFound"
+                    "Event"
                 ],
-                "summary": "Update a lost and found item",
-                "operationId": "update-lost-and-found-item",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Lost and found item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item name",
-                        "name": "item_name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item description",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Date the item was found",
-                        "name": "date_found",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Location where the item was found",
-                        "name": "location_found",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status of the lost and found item",
-                        "name": "status",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image file of the lost and found item",
-                        "name": "image_file",
-                        "in": "formData"
+                        "description": "Daily Schedule data to update",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Event"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundErrorResponse"
+                            "$ref": "#/definitions/restTypes.StatusResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundErrorResponse"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An event with the ID already exists or is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.LostAndFoundErrorResponse"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/data/lost-and-found/image/{imageID}": {
-            "get": {
-                "description": "Fetches the image file for a lost and found item with the specified ID from the database and returns it as a JPEG image in the response body.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "schedule:write"
+                        ]
+                    }
+                ],
+                "description": "Deletes the daily schedule event based on the ID and date provided in the JSON",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "image/jpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "LostAndFound"
+                    "Event"
                 ],
-                "summary": "Get the image file for a lost and found item by ID.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The ID of the lost and found item to retrieve the image file for.",
-                        "name": "imageID",
-                        "in": "path",
-                        "required": true
+                        "description": "Data to delete an event from the daily schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Event"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The image file for the specified lost and found item.",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.StatusResponse"
                         }
                     },
-                    "404": {
-                        "description": "The specified lost and found item ID was not found in the database.",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/data/school-store/": {
-            "get": {
-                "description": "Retrieves a list of items from the School Store database",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "School Store"
-                ],
-                "summary": "Get a list of items from the School Store",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.SchoolStoreResponse"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request parameters",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Adds a new item to the School Store database",
+            }
+        },
+        "/data/daily-schedule/events": {
+            "get": {
+                "description": "Retrieves all events for the specified date",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "School Store"
+                    "Event"
                 ],
-                "summary": "Add an item to the School Store",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Name of the item to add",
-                        "name": "item_name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Description of the item to add",
-                        "name": "description",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Price of the item to add",
-                        "name": "price",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Category of the item to add",
-                        "name": "category",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image file of the item to add",
-                        "name": "image_file",
-                        "in": "formData",
+                        "description": "Date of events to retrieve (in the format 'YYYY-MM-DD')",
+                        "name": "date",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1167,44 +1323,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                            "$ref": "#/definitions/restTypes.GetEventsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/school-store/image/{item_id}": {
+        "/data/daily-schedule/image": {
             "get": {
-                "description": "Retrieves an image for a specified item from the School Store database",
                 "consumes": [
-                    "application/json"
+                    "*/*"
                 ],
                 "produces": [
-                    "image/jpeg"
+                    "image/*"
                 ],
                 "tags": [
-                    "School Store"
+                    "Event"
                 ],
-                "summary": "Get an image for an item from the School Store",
+                "summary": "Get the image file for the daily schedule of the specified date or the current date",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID of the item to retrieve the image for",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "The date for which to retrieve the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used.",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1214,35 +1368,35 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request parameters",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Item not found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/data/school-store/{item_id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
-                        "Bearer": []
+                        "Bearer": [
+                            "schedule:write"
+                        ]
                     }
                 ],
-                "description": "Updates an existing item in the School Store database",
+                "description": "Uploads the daily schedule image for the provided date to the database",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1250,165 +1404,1862 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "School Store"
+                    "Event"
                 ],
-                "summary": "Update an item in the School Store",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID of the item to update",
-                        "name": "item_id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "The daily schedule image file",
+                        "name": "image",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "New name of the item",
-                        "name": "item_name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "New description of the item",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
+                        "description": "The date for which the image is uploaded (format: 2006-01-02)",
+                        "name": "date",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The image for the date is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "schedule:write"
+                        ]
+                    }
+                ],
+                "description": "Deletes the daily schedule image for a specific date from the database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The date for which to delete the daily schedule image in the format 'YYYY-MM-DD'. If not provided, the current date is used.",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No image for the date",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/food-menu/": {
+            "get": {
+                "description": "Retrieves the breakfast, lunch, and dinner menu for the current date from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "summary": "Get the food menu for the current date",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Add a new food menu to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "summary": "Add a food menu",
+                "operationId": "addFoodMenu",
+                "parameters": [
+                    {
+                        "description": "Food menu to add",
+                        "name": "foodMenu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A menu for the date already exists or is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/food-menu/all": {
+            "get": {
+                "description": "Retrieves the breakfast, lunch, and dinner menus from the database, a page at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Most items to return, 1 to 200; all of them when absent",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "date",
+                        "description": "Comma separated fields, - for descending: date",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menus on or after this date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menus on or before this date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.AllMenuResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/food-menu/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Upserts many days of menus by date in one transaction. The body has the format of GET /data/food-menu/all (and of the scraped food.json dumps): breakfast, lunch and dinner are JSON encoded arrays of {name, ingredients, group}. Dates may be YYYY-MM-DD or RFC 3339. If any day is invalid nothing is written and the error lists every problem.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "summary": "Bulk import food menus",
+                "parameters": [
+                    {
+                        "description": "Menus to import",
+                        "name": "menus",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.AllMenuResponse"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import the 7 days starting on this date (YYYY-MM-DD)",
+                        "name": "week",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import this month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import days on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only import days on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without writing anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.MenuImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A menu to import is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/food-menu/{date}": {
+            "get": {
+                "description": "Retrieves the breakfast, lunch, and dinner menu for a specific date from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The date of the food menu (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Update the food menu for the specified date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu"
+                ],
+                "summary": "Update a food menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date of the food menu to update (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New values for the food menu",
+                        "name": "foodMenu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/databaseTypes.FoodMenu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A menu for the new date already exists or is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Delete a food menu from the database for a given date",
+                "tags": [
+                    "FoodMenu"
+                ],
+                "summary": "Delete a food menu",
+                "operationId": "DeleteFoodMenu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The date of the food menu to delete",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/games/": {
+            "get": {
+                "description": "Retrieves data about sports games and their results.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SportsData"
+                ],
+                "summary": "Get sports game data",
+                "operationId": "get-sports-game-data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Most items to return, 1 to 200; all of them when absent",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "game_schedule",
+                        "description": "Comma separated fields, - for descending: id, sport_name, category, game_schedule",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only games of this sport",
+                        "name": "sport_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only games in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only home or only away games",
+                        "name": "home_or_away",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only games on or after this date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only games on or before this date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of sports game data",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.SportsGameDataList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/lost-and-found/": {
+            "get": {
+                "description": "Retrieves a page of lost and found items from the database, optionally filtered and sorted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LostAndFound"
+                ],
+                "summary": "Get lost and found items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Most items to return, 1 to 200; all of them when absent",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-date_found",
+                        "description": "Comma separated fields, - for descending: id, item_name, date_found, location_found, status",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "Only items with this status: 0 lost, 1 found",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items found at this location",
+                        "name": "location_found",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items found on or after this date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items found on or before this date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.LostAndFoundResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "lost_and_found:create"
+                        ]
+                    }
+                ],
+                "description": "Adds a lost and found item to the database and uploads an image file",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LostAndFound"
+                ],
+                "summary": "Add a lost and found item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the lost/found item",
+                        "name": "item_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description of the lost/found item",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date the item was found (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_found",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location where the item was found",
+                        "name": "location_found",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "Status of the item: 0 lost, 1 found",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image of the lost/found item",
+                        "name": "image_file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.LostAndFoundPostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/lost-and-found/image/{id}": {
+            "get": {
+                "description": "Fetches the image file for a lost and found item with the specified ID from the database and returns it as a JPEG image in the response body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "LostAndFound"
+                ],
+                "summary": "Get the image file for a lost and found item by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The ID of the lost and found item to retrieve the image file for.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The image file for the specified lost and found item.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "The specified lost and found item ID was not found in the database.",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/lost-and-found/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "lost_and_found:manage"
+                        ]
+                    }
+                ],
+                "description": "Update an existing lost and found item in the database with the specified ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LostAndFound"
+                ],
+                "summary": "Update a lost and found item",
+                "operationId": "update-lost-and-found-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lost and found item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item name",
+                        "name": "item_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date the item was found (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_found",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location where the item was found",
+                        "name": "location_found",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "Status of the item: 0 lost, 1 found",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file of the lost and found item",
+                        "name": "image_file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.LostAndFoundPostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "lost_and_found:manage"
+                        ]
+                    }
+                ],
+                "description": "Deletes a lost and found item from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LostAndFound"
+                ],
+                "summary": "Delete a lost and found item",
+                "operationId": "delete-lost-and-found-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lostAndFound.deleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/school-store/": {
+            "get": {
+                "description": "Retrieves a list of items from the School Store database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "School Store"
+                ],
+                "summary": "Get a list of items from the School Store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Most items to return, 1 to 200; all of them when absent",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "product_name",
+                        "description": "Comma separated fields, - for descending: id, product_name, price, category, date_added",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items costing at least this much",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items costing at most this much",
+                        "name": "price_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.SchoolStoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "store:write"
+                        ]
+                    }
+                ],
+                "description": "Adds a new item to the School Store database",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "School Store"
+                ],
+                "summary": "Add an item to the School Store",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the item to add",
+                        "name": "item_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description of the item to add",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price of the item to add",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category of the item to add",
+                        "name": "category",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file of the item to add",
+                        "name": "image_file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.SchoolStorePostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/school-store/image/{item_id}": {
+            "get": {
+                "description": "Retrieves an image for a specified item from the School Store database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "School Store"
+                ],
+                "summary": "Get an image for an item from the School Store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the item to retrieve the image for",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/school-store/{item_id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "store:write"
+                        ]
+                    }
+                ],
+                "description": "Updates an existing item in the School Store database",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "School Store"
+                ],
+                "summary": "Update an item in the School Store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the item to update",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "New name of the item",
+                        "name": "item_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "New description of the item",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
                         "type": "number",
                         "description": "New price of the item",
                         "name": "price",
                         "in": "formData"
                     },
+                    {
+                        "type": "integer",
+                        "description": "New category of the item",
+                        "name": "category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "New number of the item in stock",
+                        "name": "stock",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "New image file of the item",
+                        "name": "image_file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.SchoolStorePostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "store:write"
+                        ]
+                    }
+                ],
+                "description": "Deletes an item from the School Store database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "School Store"
+                ],
+                "summary": "Delete an item from the School Store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the item to delete",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/data/sports/": {
+            "get": {
+                "description": "Retrieves data about sports teams and their coaches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SportsData"
+                ],
+                "summary": "Get sports data",
+                "operationId": "get-sports-data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Most items to return, 1 to 200; all of them when absent",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "New category of the item",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "sport_name",
+                        "description": "Comma separated fields, - for descending: id, sport_name, category, season",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only teams of this sport",
+                        "name": "sport_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only teams in this category",
                         "name": "category",
-                        "in": "formData"
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "New image file of the item",
-                        "name": "image_file",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "Only teams of this season",
+                        "name": "season",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of sports data",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.SportsDataList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Always succeeds while the process is serving requests.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.HealthResponse"
+                        }
                     }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Succeeds when the database is reachable and its schema is up to date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
                 ],
+                "summary": "Readiness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.SchoolStorePostResponse"
+                            "$ref": "#/definitions/restTypes.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/data/food-menu/": {
+            "get": {
+                "description": "Retrieves the breakfast, lunch, and dinner dishes for the current date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu v2"
+                ],
+                "summary": "Get the food menu for the current date",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Menu"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Add the dishes of a new day to the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FoodMenu v2"
+                ],
+                "summary": "Add a food menu",
+                "parameters": [
+                    {
+                        "description": "Food menu to add",
+                        "name": "foodMenu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Menu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.StatusResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A menu for the date already exists or is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Deletes an item from the School Store database",
+            }
+        },
+        "/v2/data/food-menu/all": {
+            "get": {
+                "description": "Retrieves the breakfast, lunch, and dinner dishes of every day, a page at a time",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "School Store"
+                    "FoodMenu v2"
                 ],
-                "summary": "Delete an item from the School Store",
+                "summary": "Get all the food menus",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID of the item to delete",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
+                        "default": 50,
+                        "description": "Most items to return, 1 to 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "date",
+                        "description": "Comma separated fields, - for descending: date",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menus on or after this date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only menus on or before this date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                            "$ref": "#/definitions/restTypes.MenuList"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Item not found",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/data/sports/": {
+        "/v2/data/food-menu/{date}": {
             "get": {
-                "description": "Retrieves data about sports teams and their coaches.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the breakfast, lunch, and dinner dishes for a specific date",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SportsData"
+                    "FoodMenu v2"
+                ],
+                "summary": "Get the food menu for a specific date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The date of the food menu (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get sports data",
-                "operationId": "get-sports-data",
                 "responses": {
                     "200": {
-                        "description": "List of sports data",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/restTypes.SportsDataList"
+                            "$ref": "#/definitions/restTypes.Menu"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/lost-and-found/{id}": {
-            "delete": {
-                "description": "Deletes a lost and found item from the database",
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": [
+                            "food_menu:write"
+                        ]
+                    }
+                ],
+                "description": "Replace the dishes of the food menu for the specified date",
                 "consumes": [
                     "application/json"
                 ],
@@ -1416,48 +3267,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "LostAndFound"
+                    "FoodMenu v2"
                 ],
-                "summary": "Delete a lost and found item",
-                "operationId": "delete-lost-and-found-item",
+                "summary": "Update a food menu",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Item ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Date of the food menu to update (YYYY-MM-DD)",
+                        "name": "date",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New dishes of the food menu",
+                        "name": "foodMenu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.Menu"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/lostAndFound.deleteResponse"
+                            "$ref": "#/definitions/restTypes.StatusResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Item not found",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
-                    "405": {
-                        "description": "Method not allowed",
+                    "409": {
+                        "description": "A menu for the new date already exists or is in the trash",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/restTypes.ErrorResponse"
                         }
                     }
                 }
@@ -1465,11 +3342,77 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "databaseTypes.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "description": "Before and After are the entity as JSON, absent when it did not exist.",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-05-22T08:30:00Z"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "2023-05-22"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "food_menu"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "192.0.2.1"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f2a9c1e5b7d4e60"
+                },
+                "user_id": {
+                    "description": "UserID is the user who made the change, absent for failed logins.",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "databaseTypes.Dish": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "N/A"
+                },
+                "ingredients": {
+                    "type": "string",
+                    "example": "Liquid Egg, Oil"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Scrambled Eggs"
+                }
+            }
+        },
         "databaseTypes.FoodMenu": {
             "type": "object",
+            "required": [
+                "date"
+            ],
             "properties": {
                 "breakfast": {
                     "type": "string",
+                    "maxLength": 10000,
                     "example": "Omelette"
                 },
                 "date": {
@@ -1478,6 +3421,7 @@ const docTemplate = `{
                 },
                 "dinner": {
                     "type": "string",
+                    "maxLength": 10000,
                     "example": "Grilled chicken"
                 },
                 "id": {
@@ -1486,6 +3430,7 @@ const docTemplate = `{
                 },
                 "lunch": {
                     "type": "string",
+                    "maxLength": 10000,
                     "example": "Pasta"
                 }
             }
@@ -1569,6 +3514,52 @@ const docTemplate = `{
                 }
             }
         },
+        "databaseTypes.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-18T08:00:00Z"
+                },
+                "current": {
+                    "description": "Current marks the session of the request listing the sessions.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "device_name": {
+                    "description": "DeviceName is given by the client at login.",
+                    "type": "string",
+                    "example": "Pixel 8"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9b2f6c1e-3d4a-4c5b-8e7f-0a1b2c3d4e5f"
+                },
+                "ip": {
+                    "description": "IP and LastUsedAt are those of the last login or refresh.",
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2026-10-18T09:45:00Z"
+                },
+                "token_prefix": {
+                    "description": "TokenPrefix is the start of the session's refresh token; only a\ndigest of the rest is stored.",
+                    "type": "string",
+                    "example": "3q2-7wVp"
+                },
+                "user_agent": {
+                    "description": "UserAgent is the User-Agent header of the login.",
+                    "type": "string",
+                    "example": "SchoolApp/2.3 (Android 14)"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "databaseTypes.SportsGame": {
             "type": "object",
             "properties": {
@@ -1640,6 +3631,28 @@ const docTemplate = `{
                 }
             }
         },
+        "databaseTypes.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2023-05-22T08:30:00Z"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "store_item"
+                },
+                "name": {
+                    "description": "Name is the title, name or date the entity is known by.",
+                    "type": "string",
+                    "example": "Backpack"
+                }
+            }
+        },
         "databaseTypes.User": {
             "type": "object",
             "properties": {
@@ -1685,6 +3698,74 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/databaseTypes.FoodMenu"
                     }
+                },
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.AuditEntry"
+                    }
+                },
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.BackupResponse": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
@@ -1704,25 +3785,52 @@ const docTemplate = `{
             "properties": {
                 "code": {
                     "description": "HTTP status code of the error response.\n\nExample: 400\n\nRequired: true",
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 400
+                },
+                "error": {
+                    "description": "Error names the kind of error, derived from the status code.\n\nExample: bad_request",
+                    "type": "string",
+                    "example": "bad_request"
+                },
+                "fields": {
+                    "description": "Fields lists the problems with individual input fields, if any.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/restTypes.FieldError"
+                    }
                 },
                 "message": {
                     "description": "Error message.\n\nExample: Invalid request\n\nRequired: true",
-                    "type": "string"
+                    "type": "string",
+                    "example": "Invalid request"
+                },
+                "request_id": {
+                    "description": "RequestID identifies the request in the server logs.",
+                    "type": "string",
+                    "example": "3f2a9c1e5b7d4e60"
                 }
             }
         },
         "restTypes.Event": {
             "type": "object",
+            "required": [
+                "end",
+                "id",
+                "start",
+                "title"
+            ],
             "properties": {
                 "color": {
                     "description": "Color representation of the event",
                     "type": "string",
+                    "maxLength": 50,
                     "example": "rgba(220,114,114,0.6)"
                 },
                 "description": {
                     "description": "Description of the event",
                     "type": "string",
+                    "maxLength": 2000,
                     "example": "asdasd"
                 },
                 "end": {
@@ -1733,11 +3841,13 @@ const docTemplate = `{
                 "id": {
                     "description": "Unique identifier for the schedule",
                     "type": "string",
+                    "maxLength": 100,
                     "example": "1"
                 },
                 "location": {
                     "description": "Location of the event",
                     "type": "string",
+                    "maxLength": 200,
                     "example": "sadsadad"
                 },
                 "start": {
@@ -1748,15 +3858,30 @@ const docTemplate = `{
                 "status": {
                     "description": "Status of the event (e.g., \"busy\" or \"free\")",
                     "type": "string",
+                    "maxLength": 50,
                     "example": "busy"
                 },
                 "title": {
                     "description": "Title of the event",
                     "type": "string",
+                    "maxLength": 200,
                     "example": "New event"
                 }
             }
         },
+        "restTypes.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "date"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be a date in the form YYYY-MM-DD"
+                }
+            }
+        },
         "restTypes.GetEventsResponse": {
             "type": "object",
             "properties": {
@@ -1768,17 +3893,104 @@ const docTemplate = `{
                 }
             }
         },
+        "restTypes.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Result of each readiness check, \"ok\" when it passed.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "restTypes.ImportPeopleReport": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Accounts created, with the initial password to hand out.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/restTypes.ImportedPerson"
+                    }
+                },
+                "departed": {
+                    "description": "Emails of accounts no longer listed in the directory. They are not deleted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "skipped": {
+                    "description": "Entries that could not be imported, with the reason.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Emails of accounts whose name was changed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "restTypes.ImportedPerson": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "initial_password": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "user_type": {
+                    "type": "integer"
+                }
+            }
+        },
         "restTypes.LoginRequest": {
             "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
             "properties": {
+                "device_name": {
+                    "description": "Name of the device logging in, shown in the list of sessions.\n\nRequired: false",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Pixel 8"
+                },
                 "password": {
                     "description": "User's password.\n\nExample: mypassword123\n\nRequired: true",
                     "type": "string",
+                    "maxLength": 72,
                     "example": "password1"
                 },
                 "username": {
                     "description": "User's email or username.\n\nRequired: true",
                     "type": "string",
+                    "maxLength": 254,
                     "example": "johnsmith@example.com"
                 }
             }
@@ -1786,18 +3998,28 @@ const docTemplate = `{
         "restTypes.LoginResponse": {
             "type": "object",
             "properties": {
+                "expires_in": {
+                    "description": "Seconds until the access token expires.\n\nExample: 900\n\nRequired: true",
+                    "type": "integer",
+                    "example": 900
+                },
                 "message": {
                     "description": "Message indicating the result of the login attempt.\n\nExample: Login successful\n\nRequired: true",
                     "type": "string",
                     "example": "Login successful"
                 },
+                "refresh_token": {
+                    "description": "Token to exchange for new tokens at /auth/refresh once the access\ntoken expires. It can be used only once.\n\nRequired: true",
+                    "type": "string",
+                    "example": "3q2-7wVp0tT9lq5yQX3v2fHk1J8cYl4mN6bR0sDxA1E"
+                },
                 "status": {
                     "description": "Status of the login attempt.\n\nExample: success\n\nRequired: true",
                     "type": "string",
                     "example": "success"
                 },
                 "token": {
-                    "description": "JWT token to be used for authentication in future requests.\n\nExample: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...\n\nRequired: true",
+                    "description": "JWT access token to be used for authentication in future requests.\n\nExample: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...\n\nRequired: true",
                     "type": "string",
                     "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"
                 },
@@ -1811,25 +4033,196 @@ const docTemplate = `{
                 }
             }
         },
-        "restTypes.LostAndFoundErrorResponse": {
+        "restTypes.LostAndFoundPostResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "restTypes.LostAndFoundPostResponse": {
+        "restTypes.LostAndFoundResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.LostAndFound"
+                    }
+                },
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.Menu": {
             "type": "object",
+            "required": [
+                "date"
+            ],
             "properties": {
+                "breakfast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.Dish"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "dinner": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.Dish"
+                    }
+                },
                 "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lunch": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.Dish"
+                    }
+                }
+            }
+        },
+        "restTypes.MenuImportDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2023-05-22"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "unchanged"
+                },
+                "result": {
+                    "description": "inserted, updated or skipped",
+                    "type": "string",
+                    "example": "inserted"
+                }
+            }
+        },
+        "restTypes.MenuImportReport": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/restTypes.MenuImportDay"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Problems that stopped the import; nothing is written when there are any.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/restTypes.FieldError"
+                    }
+                },
+                "inserted": {
+                    "type": "integer"
+                },
+                "skipped": {
                     "type": "integer"
                 },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "restTypes.MenuList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/restTypes.Menu"
+                    }
+                },
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token from the last login or refresh.\n\nRequired: true",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "3q2-7wVp0tT9lq5yQX3v2fHk1J8cYl4mN6bR0sDxA1E"
+                }
+            }
+        },
+        "restTypes.RestoreResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "description": "Item is the restored item as it was listed in the trash.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/databaseTypes.TrashItem"
+                        }
+                    ]
+                },
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Item restored"
                 },
                 "status": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
@@ -1850,33 +4243,148 @@ const docTemplate = `{
         "restTypes.SchoolStoreResponse": {
             "type": "object",
             "properties": {
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
                 "list": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/databaseTypes.SchoolStore"
                     }
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.SessionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.Session"
+                    }
                 }
             }
         },
         "restTypes.SportsDataList": {
             "type": "object",
             "properties": {
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
                 "list": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/databaseTypes.SportsInfo"
                     }
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
                 }
             }
         },
         "restTypes.SportsGameDataList": {
             "type": "object",
             "properties": {
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
                 "list": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/databaseTypes.SportsGame"
                     }
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "restTypes.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Food menu added successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "restTypes.TrashResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/databaseTypes.TrashItem"
+                    }
+                },
+                "limit": {
+                    "description": "Most items in one page, 0 when the page holds the rest of the list.",
+                    "type": "integer",
+                    "example": 50
+                },
+                "next": {
+                    "description": "Path and query of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "/data/lost-and-found/?cursor=bzo1MA"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page.",
+                    "type": "string",
+                    "example": "bzo1MA"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, on every page.",
+                    "type": "integer",
+                    "example": 120
                 }
             }
         }
@@ -0,0 +1,130 @@
+package httpCache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"server/router"
+	"strings"
+	"time"
+)
+
+// Middleware returns route middleware that, for GET and HEAD requests, sends
+// the Cache-Control header policies gives for the route's pattern, or
+// fallback, and tags successful responses with an ETag hashed from their
+// body. Requests whose If-None-Match or If-Modified-Since show the client
+// already has the response get a 304 without it. Handlers that know when
+// their data last changed set it with SetLastModified.
+func Middleware(policies map[string]string, fallback string) router.RouteMiddleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		policy, ok := policies[pattern]
+		if !ok {
+			policy = fallback
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The whole body is needed to hash it, so hold it back until
+			// the handler is done. HEAD is served as GET, as handlers such
+			// as http.ServeFile write no body for it, so the ETag is that of
+			// the GET response; the server drops the body of HEAD responses
+			get := r
+			if r.Method == http.MethodHead {
+				get = r.Clone(r.Context())
+				get.Method = http.MethodGet
+			}
+			buffer := &bufferedWriter{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(buffer, get)
+			header := w.Header()
+			if buffer.status != http.StatusOK {
+				w.WriteHeader(buffer.status)
+				w.Write(buffer.body.Bytes())
+				return
+			}
+
+			if policy != "" && header.Get("Cache-Control") == "" {
+				header.Set("Cache-Control", policy)
+			}
+			if header.Get("ETag") == "" {
+				header.Set("ETag", ETag(buffer.body.Bytes()))
+			}
+			if notModified(r, header) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(buffer.body.Bytes())
+		})
+	}
+}
+
+// SetLastModified sends t as the Last-Modified time of the response, unless
+// it is zero.
+func SetLastModified(w http.ResponseWriter, t time.Time) {
+	if !t.IsZero() {
+		w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+	}
+}
+
+// ETag returns a strong entity tag for body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified evaluates the conditional headers of r against the response
+// header. If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, header http.Header) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		return etagMatches(match, header.Get("ETag"))
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches compares the tags listed in an If-None-Match header with etag,
+// ignoring whether either is weak.
+func etagMatches(list, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (b *bufferedWriter) Header() http.Header { return b.header }
+
+func (b *bufferedWriter) WriteHeader(status int) {
+	if !b.wrote {
+		b.status = status
+		b.wrote = true
+	}
+}
+
+func (b *bufferedWriter) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
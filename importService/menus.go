@@ -0,0 +1,179 @@
+package importService
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"server/databaseControllers"
+	"server/databaseTypes"
+	"server/restTypes"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMenus is returned when the menus to import are malformed. Nothing
+// is written in that case.
+var ErrInvalidMenus = errors.New("invalid menus")
+
+// DateRange limits an import to the days From to To, both included. A zero
+// From or To leaves that end open.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r DateRange) contains(day time.Time) bool {
+	return (r.From.IsZero() || !day.Before(r.From)) && (r.To.IsZero() || !day.After(r.To))
+}
+
+// ParseDateRange builds a range from either a week (the 7 days starting on a
+// YYYY-MM-DD date), a month (YYYY-MM), or explicit from/to dates. Empty
+// arguments are ignored and no arguments at all means every day.
+func ParseDateRange(from, to, week, month string) (DateRange, error) {
+	var r DateRange
+	given := 0
+	for _, v := range []string{week, month, from + to} {
+		if v != "" {
+			given++
+		}
+	}
+	if given > 1 {
+		return r, fmt.Errorf("use only one of week, month or from/to")
+	}
+
+	var err error
+	switch {
+	case week != "":
+		if r.From, err = time.Parse("2006-01-02", week); err != nil {
+			return r, fmt.Errorf("week must be a YYYY-MM-DD date: %w", err)
+		}
+		r.To = r.From.AddDate(0, 0, 6)
+	case month != "":
+		if r.From, err = time.Parse("2006-01", month); err != nil {
+			return r, fmt.Errorf("month must look like YYYY-MM: %w", err)
+		}
+		r.To = r.From.AddDate(0, 1, -1)
+	default:
+		if from != "" {
+			if r.From, err = time.Parse("2006-01-02", from); err != nil {
+				return r, fmt.Errorf("from must be a YYYY-MM-DD date: %w", err)
+			}
+		}
+		if to != "" {
+			if r.To, err = time.Parse("2006-01-02", to); err != nil {
+				return r, fmt.Errorf("to must be a YYYY-MM-DD date: %w", err)
+			}
+		}
+		if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+			return r, fmt.Errorf("to must not be before from")
+		}
+	}
+	return r, nil
+}
+
+// ImportMenus reads menus in the format of food.json, a restTypes.AllMenuResponse
+// whose meals are JSON encoded arrays of databaseTypes.Dish, and upserts the
+// days within dates by date in one transaction. If any day is invalid the
+// report lists every problem, ErrInvalidMenus is returned and nothing is written.
+func ImportMenus(menus databaseControllers.FoodMenuRepo, r io.Reader, dates DateRange, dryRun bool) (*restTypes.MenuImportReport, error) {
+	var input restTypes.AllMenuResponse
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMenus, err)
+	}
+
+	report := &restTypes.MenuImportReport{DryRun: dryRun, Days: []restTypes.MenuImportDay{}}
+	var toSave []databaseTypes.FoodMenu
+	var toSaveDays []int
+	seen := map[string]bool{}
+	for i, menu := range input.Items {
+		day, err := parseMenuDate(menu.Date)
+		if err != nil {
+			report.Errors = append(report.Errors, restTypes.FieldError{Field: fmt.Sprintf("items[%d].date", i), Message: err.Error()})
+			continue
+		}
+		date := day.Format("2006-01-02")
+		if seen[date] {
+			report.Errors = append(report.Errors, restTypes.FieldError{Field: fmt.Sprintf("items[%d].date", i), Message: date + " is listed twice"})
+			continue
+		}
+		seen[date] = true
+
+		if !dates.contains(day) {
+			report.Days = append(report.Days, restTypes.MenuImportDay{Date: date, Result: "skipped", Reason: "outside the requested range"})
+			report.Skipped++
+			continue
+		}
+
+		menu.Date = date
+		for _, meal := range []struct {
+			name string
+			list *string
+		}{{"breakfast", &menu.Breakfast}, {"lunch", &menu.Lunch}, {"dinner", &menu.Dinner}} {
+			if err := validateDishes(meal.list); err != nil {
+				report.Errors = append(report.Errors, restTypes.FieldError{Field: fmt.Sprintf("items[%d].%s", i, meal.name), Message: err.Error()})
+			}
+		}
+		report.Days = append(report.Days, restTypes.MenuImportDay{Date: date})
+		toSave = append(toSave, menu)
+		toSaveDays = append(toSaveDays, len(report.Days)-1)
+	}
+	if len(report.Errors) > 0 {
+		return report, ErrInvalidMenus
+	}
+
+	changes, err := menus.UpsertAll(toSave, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for i, change := range changes {
+		day := &report.Days[toSaveDays[i]]
+		switch change {
+		case databaseControllers.MenuInserted:
+			day.Result = "inserted"
+			report.Inserted++
+		case databaseControllers.MenuUpdated:
+			day.Result = "updated"
+			report.Updated++
+		default:
+			day.Result = "skipped"
+			day.Reason = "unchanged"
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
+
+// parseMenuDate accepts both the YYYY-MM-DD dates the API uses and the
+// RFC 3339 timestamps of the scraped dumps.
+func parseMenuDate(s string) (time.Time, error) {
+	if day, err := time.Parse("2006-01-02", s); err == nil {
+		return day, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is neither YYYY-MM-DD nor RFC 3339", s)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// validateDishes checks that meal holds a JSON array of dishes that all have
+// a name. An empty meal is stored as an empty array.
+func validateDishes(meal *string) error {
+	if strings.TrimSpace(*meal) == "" {
+		*meal = "[]"
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(*meal))
+	decoder.DisallowUnknownFields()
+	var dishes []databaseTypes.Dish
+	if err := decoder.Decode(&dishes); err != nil {
+		return fmt.Errorf("not a JSON array of dishes: %v", err)
+	}
+	for i, dish := range dishes {
+		if strings.TrimSpace(dish.Name) == "" {
+			return fmt.Errorf("dish %d has no name", i+1)
+		}
+	}
+	return nil
+}
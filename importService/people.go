@@ -0,0 +1,196 @@
+package importService
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"server/databaseControllers"
+	"server/databaseTypes"
+	"server/restTypes"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Person is one entry of People/students.json or People/teachers.json.
+type Person struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	ParentEmail string `json:"parentEmail"`
+	State       string `json:"state"`
+}
+
+// peopleFiles maps the files of the People directory to the user type of the people they list.
+var peopleFiles = []struct {
+	file     string
+	userType int
+}{
+	{"students.json", databaseTypes.UserTypeStudent},
+	{"teachers.json", databaseTypes.UserTypeTeacher},
+}
+
+// peopleImport is what an import will change, gathered before anything is
+// written.
+type peopleImport struct {
+	report *restTypes.ImportPeopleReport
+	// seen holds the emails of every file read so far.
+	seen    map[string]string
+	added   []databaseTypes.User
+	renamed []databaseTypes.User
+}
+
+// ImportPeople upserts everyone listed in the People directory dir into
+// users. New accounts get a random initial password, which is returned in
+// the report and stored only as a bcrypt hash. Existing accounts, matched by
+// email, have their name updated; their type and password are left alone.
+// Accounts of an imported type that are no longer listed are reported as
+// departures but not deleted, so running the import again changes nothing.
+// A missing teachers.json is skipped. The changes are written in one
+// transaction, so an import that fails changes nothing. With dryRun nothing
+// is written.
+func ImportPeople(users databaseControllers.UserRepo, dir string, dryRun bool) (*restTypes.ImportPeopleReport, error) {
+	plan := &peopleImport{
+		report: &restTypes.ImportPeopleReport{
+			DryRun:   dryRun,
+			Added:    []restTypes.ImportedPerson{},
+			Updated:  []string{},
+			Departed: []string{},
+			Skipped:  []string{},
+		},
+		seen: map[string]string{},
+	}
+	for _, source := range peopleFiles {
+		path := filepath.Join(dir, source.file)
+		people, err := readPeople(path)
+		if os.IsNotExist(err) && source.userType != databaseTypes.UserTypeStudent {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := plan.add(users, people, source.file, source.userType, dryRun); err != nil {
+			return nil, err
+		}
+	}
+	if dryRun {
+		return plan.report, nil
+	}
+	if err := users.Import(plan.added, plan.renamed); err != nil {
+		return nil, err
+	}
+	return plan.report, nil
+}
+
+func readPeople(path string) ([]Person, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var people []Person
+	if err := json.Unmarshal(data, &people); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return people, nil
+}
+
+// add plans the import of the people listed in file as users of userType,
+// hashing the initial passwords unless dryRun.
+func (p *peopleImport) add(users databaseControllers.UserRepo, people []Person, file string, userType int, dryRun bool) error {
+	report := p.report
+	listed := map[string]bool{}
+	for i, person := range people {
+		email := strings.ToLower(strings.TrimSpace(person.Email))
+		firstName, lastName := splitName(person.Name)
+		switch {
+		case email == "" || !strings.Contains(email, "@"):
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s entry %d (%s): no valid email", file, i+1, person.Name))
+			continue
+		case firstName == "":
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s entry %d (%s): no name", file, i+1, email))
+			continue
+		case listed[email]:
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s entry %d (%s): listed twice", file, i+1, email))
+			continue
+		case p.seen[email] != "":
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s entry %d (%s): already listed in %s", file, i+1, email, p.seen[email]))
+			continue
+		}
+		listed[email] = true
+		p.seen[email] = file
+
+		existing, err := users.GetByEmail(email)
+		if err == databaseControllers.ErrNotFound {
+			password, err := newPassword()
+			if err != nil {
+				return err
+			}
+			report.Added = append(report.Added, restTypes.ImportedPerson{
+				Email:           email,
+				FirstName:       firstName,
+				LastName:        lastName,
+				UserType:        userType,
+				InitialPassword: password,
+			})
+			if dryRun {
+				continue
+			}
+			// The password is 80 random bits, which a higher cost would not
+			// make any harder to guess, and a roster has hundreds of them
+			hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+			if err != nil {
+				return err
+			}
+			p.added = append(p.added, databaseTypes.User{
+				UserType:  userType,
+				FirstName: firstName,
+				LastName:  lastName,
+				Email:     email,
+				Password:  string(hash),
+			})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if existing.FirstName == firstName && existing.LastName == lastName {
+			report.Unchanged++
+			continue
+		}
+		report.Updated = append(report.Updated, email)
+		p.renamed = append(p.renamed, databaseTypes.User{ID: existing.ID, FirstName: firstName, LastName: lastName, Email: email})
+	}
+
+	current, err := users.ListByType(userType)
+	if err != nil {
+		return err
+	}
+	for _, user := range current {
+		if !listed[strings.ToLower(user.Email)] {
+			report.Departed = append(report.Departed, user.Email)
+		}
+	}
+	return nil
+}
+
+// splitName splits a directory name such as "Joaquín Acuña Girault" into the
+// first word and the rest.
+func splitName(name string) (firstName, lastName string) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", ""
+	}
+	return parts[0], strings.Join(parts[1:], " ")
+}
+
+// newPassword returns a random 16 character initial password.
+func newPassword() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(base32.StdEncoding.EncodeToString(b)), nil
+}
@@ -0,0 +1,246 @@
+package listQuery
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"server/restTypes"
+	"server/validation"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLimit and MaxLimit bound the number of rows of one page.
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Spec declares what a list endpoint can be sorted and filtered by. Field
+// and parameter names are matched case-insensitively.
+type Spec struct {
+	// Sorts maps the fields accepted by the sort parameter to SQL expressions.
+	Sorts map[string]string
+	// DefaultSort is used when no sort parameter is given, e.g. "-date".
+	DefaultSort string
+	// Tiebreak is appended to every order so pages do not overlap, e.g. "id",
+	// or "-id" to break ties in descending order.
+	Tiebreak string
+	// Filters maps query parameters to the conditions they add.
+	Filters map[string]Filter
+	// Scope is a condition every listed row meets, e.g. "deleted_at IS NULL".
+	Scope string
+}
+
+// Filter is a condition on a list, compared with the value of a query parameter.
+type Filter struct {
+	// Expr is the SQL expression compared, e.g. "date(date_found)".
+	Expr string
+	// Op is the SQL comparison, "=" when empty.
+	Op string
+	// Type is what the value must be: "int", "number", "date", "datetime"
+	// or "" for any text. A datetime is a date or an RFC 3339 timestamp,
+	// compared in the form of SQLite's CURRENT_TIMESTAMP.
+	Type string
+}
+
+// Query is a parsed list request: its filters, order and page.
+type Query struct {
+	// Limit is the most rows of the page, or 0 for all the rows from Offset.
+	Limit  int
+	Offset int
+
+	order      []string
+	conditions []string
+	args       []interface{}
+}
+
+// Parse reads the limit, cursor, sort and filter parameters of values
+// according to spec. Unknown parameters are ignored.
+func Parse(values url.Values, spec Spec) (Query, validation.Errors) {
+	q := Query{Limit: DefaultLimit}
+	var errs validation.Errors
+
+	if s := values.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > MaxLimit {
+			errs.Add("limit", fmt.Sprintf("must be an integer from 1 to %d", MaxLimit))
+		}
+		q.Limit = n
+	}
+	if s := values.Get("cursor"); s != "" {
+		offset, err := decodeCursor(s)
+		if err != nil {
+			errs.Add("cursor", "is not a cursor returned by this endpoint")
+		}
+		q.Offset = offset
+	}
+
+	order := values.Get("sort")
+	if order == "" {
+		order = spec.DefaultSort
+	}
+	for _, field := range strings.Split(order, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		direction := " ASC"
+		if strings.HasPrefix(field, "-") {
+			field, direction = field[1:], " DESC"
+		}
+		expr, ok := spec.Sorts[field]
+		if !ok {
+			errs.Add("sort", fmt.Sprintf("cannot sort by %q; use %s", field, names(spec.Sorts)))
+			break
+		}
+		q.order = append(q.order, expr+direction)
+	}
+	if spec.Tiebreak != "" {
+		if strings.HasPrefix(spec.Tiebreak, "-") {
+			q.order = append(q.order, spec.Tiebreak[1:]+" DESC")
+		} else {
+			q.order = append(q.order, spec.Tiebreak+" ASC")
+		}
+	}
+
+	if spec.Scope != "" {
+		q.conditions = append(q.conditions, spec.Scope)
+	}
+	// Go through the parameters in order so equal queries build the same SQL
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	for _, param := range params {
+		filter, ok := spec.Filters[strings.ToLower(param)]
+		if !ok || values.Get(param) == "" {
+			continue
+		}
+		value, message := filter.parse(values.Get(param))
+		if message != "" {
+			errs.Add(strings.ToLower(param), message)
+			continue
+		}
+		op := filter.Op
+		if op == "" {
+			op = "="
+		}
+		q.conditions = append(q.conditions, filter.Expr+" "+op+" ?")
+		q.args = append(q.args, value)
+	}
+	return q, errs
+}
+
+// ParseUnpaged is Parse for the lists that returned every row before they
+// took a limit: without a limit parameter the whole list is one page, so
+// their existing clients keep getting all of it.
+func ParseUnpaged(values url.Values, spec Spec) (Query, validation.Errors) {
+	q, errs := Parse(values, spec)
+	if values.Get("limit") == "" {
+		q.Limit = 0
+	}
+	return q, errs
+}
+
+func (f Filter) parse(s string) (interface{}, string) {
+	switch f.Type {
+	case "int":
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, "must be an integer"
+		}
+		return n, ""
+	case "number":
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, "must be a number"
+		}
+		return n, ""
+	case "date":
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, "must be a date in the form YYYY-MM-DD"
+		}
+	case "datetime":
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339, s); err != nil {
+				return nil, "must be a YYYY-MM-DD date or an RFC 3339 timestamp"
+			}
+		}
+		return t.UTC().Format("2006-01-02 15:04:05"), ""
+	}
+	return s, ""
+}
+
+// Where returns the WHERE clause of the filters, or "" without filters, and
+// its arguments.
+func (q Query) Where() (string, []interface{}) {
+	if len(q.conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND "), append([]interface{}(nil), q.args...)
+}
+
+// OrderBy returns the ORDER BY clause, LIMIT and OFFSET of the query, whose
+// two arguments are appended to args.
+func (q Query) OrderBy(args []interface{}) (string, []interface{}) {
+	clause := ""
+	if len(q.order) > 0 {
+		clause = " ORDER BY " + strings.Join(q.order, ", ")
+	}
+	limit := q.Limit
+	if limit == 0 {
+		// SQLite takes a negative limit as none
+		limit = -1
+	}
+	return clause + " LIMIT ? OFFSET ?", append(args, limit, q.Offset)
+}
+
+// Page describes the page of total rows that q selected, linking to the next
+// page of the list requested by r if there is one.
+func (q Query) Page(r *http.Request, total int) restTypes.Page {
+	page := restTypes.Page{Total: total, Limit: q.Limit}
+	if next := q.Offset + q.Limit; q.Limit > 0 && next < total {
+		page.NextCursor = encodeCursor(next)
+		values := r.URL.Query()
+		values.Set("cursor", page.NextCursor)
+		link := url.URL{Path: r.URL.Path, RawQuery: values.Encode()}
+		page.Next = link.String()
+	}
+	return page
+}
+
+// Cursors are opaque to clients, so the paging scheme can change without
+// breaking them.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(string(raw), "o:") {
+		return 0, fmt.Errorf("unknown cursor %q", raw)
+	}
+	offset, err := strconv.Atoi(string(raw[2:]))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("bad cursor offset %q", raw)
+	}
+	return offset, nil
+}
+
+func names(sorts map[string]string) string {
+	list := make([]string, 0, len(sorts))
+	for name := range sorts {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
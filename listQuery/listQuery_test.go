@@ -0,0 +1,142 @@
+package listQuery
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var spec = Spec{
+	Sorts: map[string]string{
+		"date": "date",
+		"name": "item_name",
+	},
+	DefaultSort: "-date",
+	Tiebreak:    "id",
+	Filters: map[string]Filter{
+		"status": {Expr: "status", Type: "int"},
+		"from":   {Expr: "date", Op: ">=", Type: "date"},
+		"since":  {Expr: "created_at", Op: ">=", Type: "datetime"},
+	},
+	Scope: "deleted_at IS NULL",
+}
+
+func parse(t *testing.T, query string, spec Spec) Query {
+	t.Helper()
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, errs := Parse(values, spec)
+	if len(errs) > 0 {
+		t.Fatalf("Parse(%q): %v", query, errs)
+	}
+	return q
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		where string
+		args  []interface{}
+		order string
+		limit int
+	}{
+		{"", " WHERE deleted_at IS NULL", nil, " ORDER BY date DESC, id ASC LIMIT ? OFFSET ?", 50},
+		{"sort=name,-date&limit=10", " WHERE deleted_at IS NULL", nil, " ORDER BY item_name ASC, date DESC, id ASC LIMIT ? OFFSET ?", 10},
+		{"sort=Name", " WHERE deleted_at IS NULL", nil, " ORDER BY item_name ASC, id ASC LIMIT ? OFFSET ?", 50},
+		{"status=1&from=2024-01-01&unknown=x", " WHERE deleted_at IS NULL AND date >= ? AND status = ?", []interface{}{"2024-01-01", 1}, " ORDER BY date DESC, id ASC LIMIT ? OFFSET ?", 50},
+		{"since=2024-01-01T10:00:00%2B02:00", " WHERE deleted_at IS NULL AND created_at >= ?", []interface{}{"2024-01-01 08:00:00"}, " ORDER BY date DESC, id ASC LIMIT ? OFFSET ?", 50},
+	}
+	for _, tt := range tests {
+		q := parse(t, tt.query, spec)
+		where, args := q.Where()
+		if where != tt.where || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("Parse(%q).Where() = %q, %v, want %q, %v", tt.query, where, args, tt.where, tt.args)
+		}
+		order, args := q.OrderBy(nil)
+		if order != tt.order || !reflect.DeepEqual(args, []interface{}{tt.limit, 0}) {
+			t.Errorf("Parse(%q).OrderBy() = %q, %v, want %q, [%d 0]", tt.query, order, args, tt.order, tt.limit)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		field string
+	}{
+		{"limit=0", "limit"},
+		{"limit=201", "limit"},
+		{"limit=ten", "limit"},
+		{"cursor=nonsense", "cursor"},
+		{"cursor=" + url.QueryEscape(encodeCursor(-1)), "cursor"},
+		{"sort=price", "sort"},
+		{"status=lost", "status"},
+		{"from=01/01/2024", "from"},
+		{"since=yesterday", "since"},
+	}
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		_, errs := Parse(values, spec)
+		if len(errs) != 1 || errs[0].Field != tt.field {
+			t.Errorf("Parse(%q) errors = %v, want one for %s", tt.query, errs, tt.field)
+		}
+	}
+}
+
+func TestDescendingTiebreak(t *testing.T) {
+	desc := spec
+	desc.Tiebreak = "-id"
+	order, _ := parse(t, "", desc).OrderBy(nil)
+	if want := " ORDER BY date DESC, id DESC LIMIT ? OFFSET ?"; order != want {
+		t.Errorf("OrderBy() = %q, want %q", order, want)
+	}
+}
+
+func TestPages(t *testing.T) {
+	// Follow the next links through a list of 5 rows, 2 at a time
+	path := "/data/lost-and-found/?limit=2&status=1"
+	var offsets []int
+	for path != "" {
+		r := httptest.NewRequest("GET", path, nil)
+		q := parse(t, r.URL.RawQuery, spec)
+		offsets = append(offsets, q.Offset)
+		page := q.Page(r, 5)
+		if page.Total != 5 || page.Limit != 2 {
+			t.Errorf("Page = %+v", page)
+		}
+		if (page.Next == "") != (page.NextCursor == "") {
+			t.Errorf("Page has only one of next and next_cursor: %+v", page)
+		}
+		if page.Next != "" {
+			next, _ := url.Parse(page.Next)
+			if next.Path != "/data/lost-and-found/" || next.Query().Get("status") != "1" {
+				t.Errorf("next %q does not keep the path and filters", page.Next)
+			}
+		}
+		path = page.Next
+	}
+	if !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("pages start at %v, want [0 2 4]", offsets)
+	}
+}
+
+func TestParseUnpaged(t *testing.T) {
+	q, errs := ParseUnpaged(url.Values{}, spec)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if _, args := q.OrderBy(nil); !reflect.DeepEqual(args, []interface{}{-1, 0}) {
+		t.Errorf("unpaged OrderBy args = %v, want [-1 0]", args)
+	}
+	if page := q.Page(httptest.NewRequest("GET", "/data/sports/", nil), 500); page.Next != "" {
+		t.Errorf("unpaged list links to a next page %q", page.Next)
+	}
+
+	q, _ = ParseUnpaged(url.Values{"limit": {"10"}}, spec)
+	if q.Limit != 10 {
+		t.Errorf("ParseUnpaged with limit=10 has Limit %d", q.Limit)
+	}
+}
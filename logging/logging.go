@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits which incoming request IDs are trusted and echoed.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type contextKey struct{}
+
+// requestInfo is shared through the request context between the middleware
+// and the handlers, which fill in the user once they have authenticated it.
+type requestInfo struct {
+	id     string
+	userID int
+	logger *slog.Logger
+}
+
+// Setup makes a JSON logger writing to out the default for both log/slog and
+// the standard log package, and returns it.
+func Setup(out io.Writer, level slog.Level) *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level}))
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+	log.SetOutput(slogWriter{logger})
+	return logger
+}
+
+// slogWriter sends lines written with the standard log package to slog.
+type slogWriter struct {
+	logger *slog.Logger
+}
+
+func (w slogWriter) Write(p []byte) (int, error) {
+	msg := string(p)
+	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	w.logger.Info(msg)
+	return len(p), nil
+}
+
+// FromContext returns the logger for the request handled under ctx, which
+// tags every line with the request ID. Outside a request it returns the
+// default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		return info.logger
+	}
+	return slog.Default()
+}
+
+// RequestID returns the ID of the request handled under ctx, or "".
+func RequestID(ctx context.Context) string {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok {
+		return info.id
+	}
+	return ""
+}
+
+// SetUserID records the authenticated user of the request handled under ctx,
+// so it appears in the request log line and every later line for the request.
+func SetUserID(ctx context.Context, userID int) {
+	if info, ok := ctx.Value(contextKey{}).(*requestInfo); ok && info.userID != userID {
+		info.userID = userID
+		info.logger = info.logger.With("user_id", userID)
+	}
+}
+
+// Middleware gives every request an ID, echoed in the X-Request-ID response
+// header, and logs one line per request with its method, path, status,
+// latency, response size and user. A valid X-Request-ID sent by the client,
+// such as one set by a proxy, is kept.
+func Middleware(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		info := &requestInfo{id: id, logger: logger.With("request_id", id)}
+		recorder := &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), contextKey{}, info)))
+
+		attrs := []interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.Status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", recorder.Bytes,
+			"remote_addr", r.RemoteAddr,
+		}
+		level := slog.LevelInfo
+		if recorder.Status >= 500 {
+			level = slog.LevelError
+		}
+		// info.logger already carries the user ID if the request authenticated
+		info.logger.Log(r.Context(), level, "request", attrs...)
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// StatusRecorder is an http.ResponseWriter that remembers the status code and
+// the number of body bytes written.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status      int
+	Bytes       int
+	wroteHeader bool
+}
+
+func (r *StatusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.Status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *StatusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.Bytes += n
+	return n, err
+}
+
+// Flush lets streamed responses through the recorder.
+func (r *StatusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer.
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
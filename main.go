@@ -1,11 +1,32 @@
 package main
 
 import (
-	"github.com/rs/cors" // Import the cors package
+	"context"
+	"crypto/rand"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"server/apiVersion"
+	"server/authService"
+	"server/backupService"
+	"server/config"
 	"server/controllers"
+	"server/corsPolicy"
+	"server/databaseControllers"
 	_ "server/docs"
+	"server/httpCache"
+	"server/logging"
+	"server/metrics"
+	"server/restErrors"
+	"server/router"
+	"server/tlsService"
+	"server/trashService"
+	"syscall"
+	"time"
 )
 
 // Rest of your code...
@@ -19,32 +40,156 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
-	// Create a new cors handler with permissive options (allowing all origins)
-	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"}, // Allow all origins, you can restrict this to specific origins if needed
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
-		AllowCredentials: true,
+	// Log as JSON lines to stderr; the standard log package goes through it too
+	logger := logging.Setup(os.Stderr, slog.LevelInfo)
+
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	// The roles are not known to the config package
+	if err := authService.CheckSessionLimits(cfg.Auth.MaxSessions); err != nil {
+		log.Fatal(err)
+	}
+
+	// Open the one database pool shared by every handler
+	db, err := databaseControllers.Open(cfg.DatabasePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	// Run a subcommand such as "migrate up" instead of serving
+	if len(args) > 0 {
+		command, ok := commands[args[0]]
+		if !ok {
+			log.Fatalf("unknown command %q", args[0])
+		}
+		if err := command(cfg, db, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Bring the schema up to date before serving
+	if _, err := databaseControllers.MigrateUp(db); err != nil {
+		log.Fatal(err)
+	}
+
+	// Background work such as scheduled backups stops when main returns
+	stop := make(chan struct{})
+	defer close(stop)
+	backups := backupService.New(db, cfg.Backup)
+	backups.Start(stop)
+
+	repos := databaseControllers.NewRepositories(db)
+	trashService.New(repos.Trash, cfg.Trash).Start(stop)
+	metrics.NewGaugeFunc("auth_active_tokens", "Login sessions holding an unexpired refresh token.", func() (float64, error) {
+		count, err := repos.Tokens.CountActive()
+		return float64(count), err
 	})
+	signingKey := []byte(cfg.Auth.SigningKey)
+	if len(signingKey) == 0 {
+		// Clients get new access tokens with their refresh tokens after a restart
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			log.Fatal(err)
+		}
+		logger.Warn("auth.signing_key is not set; access tokens are signed with a random key and stop working on restart")
+	}
+	api := controllers.New(repos, cfg, backups, signingKey)
 
-	// Apply the cors handler to your existing handlers
-	http.Handle("/swagger/", corsHandler.Handler(httpSwagger.WrapHandler))
-	http.Handle("/auth/login", corsHandler.Handler(http.HandlerFunc(controllers.LoginHandler)))
-	//http.Handle("/auth/testToken", corsHandler.Handler(http.HandlerFunc(controllers.TestToken)))
-	//http.Handle("/auth/testToken", corsHandler.Handler(http.HandlerFunc(controllers.SchoolStoreHandler)))
-	http.Handle("/data/food-menu/", corsHandler.Handler(http.HandlerFunc(controllers.FoodMenuByHandler)))
-	http.Handle("/data/daily-schedule/image", corsHandler.Handler(http.HandlerFunc(controllers.ScheduleImageHandler)))
-	http.Handle("/data/daily-schedule/", corsHandler.Handler(http.HandlerFunc(controllers.ScheduleHandler)))
-	http.Handle("/data/lost-and-found/", corsHandler.Handler(http.HandlerFunc(controllers.LostAndFoundHandler)))
-	http.Handle("/data/sports/", corsHandler.Handler(http.HandlerFunc(controllers.SportsHandler)))
-	http.Handle("/data/games/", corsHandler.Handler(http.HandlerFunc(controllers.GamesHandler)))
-	http.Handle("/data/school-store/", corsHandler.Handler(http.HandlerFunc(controllers.SchoolStoreHandler)))
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "build/index.html")
+	// Declare the API routes; each route is counted and timed under its
+	// pattern, and its GET responses get the caching headers configured for
+	// the pattern in every API version
+	rt := router.New(metrics.Instrument, apiVersion.ByUnversionedPattern(httpCache.Middleware(cfg.Cache.Routes, cfg.Cache.Default)))
+	rt.NotFound = restErrors.Handler(http.StatusNotFound, "No such route")
+	rt.MethodNotAllowed = restErrors.Handler(http.StatusMethodNotAllowed, "Method not allowed")
+	api.Routes(rt)
+	rt.Handle(http.MethodGet, "/swagger/{path...}", httpSwagger.WrapHandler)
+	fs := http.FileServer(http.Dir(filepath.Join(cfg.BuildDir, "static")))
+	rt.Handle(http.MethodGet, "/static/{path...}", http.StripPrefix("/static", fs))
+	// Every other path belongs to the web front end
+	rt.HandleFunc(http.MethodGet, "/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join(cfg.BuildDir, "index.html"))
 	})
-	fs := http.FileServer(http.Dir("build/static/"))
-	http.Handle("/static/", http.StripPrefix("/static", fs))
 
 	// Start the server with your handlers
-	http.ListenAndServe(":8082", nil)
+	handler := logging.Middleware(logger, corsPolicy.Middleware(cfg.CORS, func(path string) string {
+		return apiVersion.Unversioned(rt.Match(path))
+	})(rt))
+	var certs *tlsService.Service
+	if cfg.TLS.Enabled() {
+		certs, err = tlsService.New(cfg.TLS)
+		if err != nil {
+			log.Fatal(err)
+		}
+		certs.Start(stop)
+		handler = certs.HSTS(handler)
+	}
+	server := newServer(cfg, cfg.Addr, handler)
+	servers := []*http.Server{server}
+	serveErr := make(chan error, 2)
+	go func() {
+		if certs == nil {
+			logger.Info("listening", "addr", cfg.Addr)
+			serveErr <- server.ListenAndServe()
+			return
+		}
+		server.TLSConfig = certs.Config()
+		logger.Info("listening", "addr", cfg.Addr, "tls", true)
+		serveErr <- server.ListenAndServeTLS("", "")
+	}()
+	if certs != nil && cfg.TLS.RedirectAddr != "" {
+		// Plain HTTP only sends clients on to HTTPS, so tokens never cross it
+		// more than once
+		redirect := newServer(cfg, cfg.TLS.RedirectAddr, logging.Middleware(logger, tlsService.Redirect(cfg.Addr)))
+		servers = append(servers, redirect)
+		go func() {
+			logger.Info("redirecting to HTTPS", "addr", cfg.TLS.RedirectAddr)
+			serveErr <- redirect.ListenAndServe()
+		}()
+	}
+	if cfg.MetricsAddr != "" {
+		// The metrics are not authenticated, so they get a listener of their
+		// own that can be kept off the public network
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsServer := newServer(cfg, cfg.MetricsAddr, mux)
+		servers = append(servers, metricsServer)
+		go func() {
+			logger.Info("serving metrics", "addr", cfg.MetricsAddr)
+			serveErr <- metricsServer.ListenAndServe()
+		}()
+	}
+
+	// Stop accepting connections on SIGINT or SIGTERM and let in-flight
+	// requests, such as image uploads, finish before exiting
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-serveErr:
+		log.Fatal(err)
+	case sig := <-signals:
+		logger.Info("shutting down", "signal", sig.String())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancel()
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("shutdown did not finish cleanly", "addr", server.Addr, "error", err)
+		}
+	}
+}
+
+// newServer returns an HTTP server on addr with the configured timeouts.
+func newServer(cfg *config.Config, addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeout),
+		ReadTimeout:       time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout:      time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.Server.IdleTimeout),
+	}
 }
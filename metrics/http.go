@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"net/http"
+	"server/logging"
+	"strconv"
+	"time"
+)
+
+// Instrument records the request count, latency and response size of every
+// request handled by next under the given route label, which should be the
+// pattern the route was registered with rather than the request path.
+func Instrument(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &logging.StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		method := normalizeMethod(r.Method)
+		HTTPRequests.Inc(route, method, strconv.Itoa(recorder.Status))
+		HTTPRequestDuration.Observe(time.Since(start).Seconds(), route, method)
+		HTTPResponseSize.Observe(float64(recorder.Bytes), route, method)
+	})
+}
+
+// normalizeMethod folds unknown methods into one label value so clients
+// cannot create new series at will.
+func normalizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "OTHER"
+}
@@ -0,0 +1,158 @@
+package rateLimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"server/config"
+	"server/metrics"
+	"server/restErrors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter keeps a token bucket per key, such as a client IP or a user ID.
+// Each bucket holds up to Burst tokens and refills at Requests per Per; a
+// request takes one token and is refused when the bucket is empty.
+type Limiter struct {
+	name     string
+	rate     float64 // tokens per second
+	burst    float64
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	lastScan time.Time
+	now      func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	seen   time.Time
+}
+
+// New returns a Limiter enforcing limit, labelled name in metrics.
+// A limit without requests returns nil, which lets everything through.
+func New(name string, limit config.Limit) *Limiter {
+	per := time.Duration(limit.Per)
+	if limit.Requests <= 0 || per <= 0 {
+		return nil
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+	return &Limiter{
+		name:    name,
+		rate:    float64(limit.Requests) / per.Seconds(),
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of key. If there is none it returns
+// false and how long until there will be.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	return l.take(key, 1)
+}
+
+// Check reports whether Allow would let key through, without taking a token.
+func (l *Limiter) Check(key string) (bool, time.Duration) {
+	return l.take(key, 0)
+}
+
+func (l *Limiter) take(key string, n float64) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.forget(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, seen: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.seen).Seconds()*l.rate)
+	b.seen = now
+	if b.tokens < 1 {
+		if n > 0 {
+			// Only count the requests refused, not those checked
+			metrics.RateLimited.Inc(l.name)
+		}
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens -= n
+	return true, 0
+}
+
+// forget drops the buckets that have refilled, at most once a minute, so
+// keys that stop sending requests do not accumulate.
+func (l *Limiter) forget(now time.Time) {
+	if now.Sub(l.lastScan) < time.Minute {
+		return
+	}
+	l.lastScan = now
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.seen) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware limits the requests passed to next by the key that key returns
+// for them. Requests key returns "" for are not limited.
+func (l *Limiter) Middleware(key func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if k := key(r); k != "" {
+				if ok, wait := l.Allow(k); !ok {
+					TooManyRequests(w, r, wait)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TooManyRequests sends a 429 response telling the client to retry after wait.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	restErrors.Write(w, r, http.StatusTooManyRequests, "Too many requests, try again later")
+}
+
+// ByIP returns a key function for Middleware that keys requests by ClientIP.
+func ByIP(trustProxy bool) func(*http.Request) string {
+	return func(r *http.Request) string {
+		return ClientIP(r, trustProxy)
+	}
+}
+
+// ClientIP returns the address of the client that sent r. Behind a reverse
+// proxy every request comes from the proxy, so with trustProxy the last
+// address the proxy added to X-Forwarded-For is used instead.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			list := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(list[len(list)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
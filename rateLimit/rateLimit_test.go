@@ -0,0 +1,135 @@
+package rateLimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"server/config"
+	"server/metrics"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock is advanced by hand.
+func newTestLimiter(t *testing.T, name string, limit config.Limit) (*Limiter, *time.Time) {
+	t.Helper()
+	l := New(name, limit)
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestAllow(t *testing.T) {
+	l, now := newTestLimiter(t, "test_allow", config.Limit{Requests: 60, Per: config.Duration(time.Minute), Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("a"); !ok {
+			t.Fatalf("request %d of the burst was refused", i+1)
+		}
+	}
+	ok, wait := l.Allow("a")
+	if ok {
+		t.Fatal("request beyond the burst was allowed")
+	}
+	if wait != time.Second {
+		t.Errorf("wait = %v, want 1s", wait)
+	}
+	if ok, _ := l.Allow("b"); !ok {
+		t.Error("another key was refused")
+	}
+
+	*now = now.Add(time.Second)
+	if ok, _ := l.Allow("a"); !ok {
+		t.Error("request after a token refilled was refused")
+	}
+	if ok, _ := l.Allow("a"); ok {
+		t.Error("second request after one token refilled was allowed")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	l, _ := newTestLimiter(t, "test_check", config.Limit{Requests: 1, Per: config.Duration(time.Minute)})
+	before := refused(t, "test_check")
+
+	// Checking takes no token, however often it is done
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Check("a"); !ok {
+			t.Fatal("Check refused a full bucket")
+		}
+	}
+	if ok, _ := l.Allow("a"); !ok {
+		t.Fatal("Allow refused after Check")
+	}
+	if ok, _ := l.Check("a"); ok {
+		t.Error("Check allowed an empty bucket")
+	}
+	if ok, _ := l.Allow("a"); ok {
+		t.Error("Allow allowed an empty bucket")
+	}
+
+	// Only the refused Allow is counted
+	if got := refused(t, "test_check") - before; got != 1 {
+		t.Errorf("rate_limited_requests_total went up by %d, want 1", got)
+	}
+}
+
+// refused returns the value of the rate limited counter of the limit.
+func refused(t *testing.T, limit string) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	prefix := `rate_limited_requests_total{limit="` + limit + `"} `
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if strings.HasPrefix(line, prefix) {
+			n, err := strconv.Atoi(strings.TrimPrefix(line, prefix))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return n
+		}
+	}
+	return 0
+}
+
+func TestNewWithoutRequests(t *testing.T) {
+	l := New("test_none", config.Limit{})
+	if l != nil {
+		t.Fatal("New of a limit without requests is not nil")
+	}
+	// A nil limiter lets everything through
+	if ok, _ := l.Allow("a"); !ok {
+		t.Error("nil limiter refused a request")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	l, _ := newTestLimiter(t, "test_middleware", config.Limit{Requests: 1, Per: config.Duration(time.Minute)})
+	handler := l.Middleware(func(r *http.Request) string {
+		return r.Header.Get("X-User")
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		user   string
+		status int
+	}{
+		{"1", http.StatusOK},
+		{"1", http.StatusTooManyRequests},
+		{"2", http.StatusOK},
+		// Requests without a key are not limited
+		{"", http.StatusOK},
+		{"", http.StatusOK},
+	}
+	for i, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("X-User", tt.user)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("request %d of user %q = %d, want %d", i+1, tt.user, w.Code, tt.status)
+		}
+		if tt.status == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "60" {
+			t.Errorf("Retry-After = %q, want 60", w.Header().Get("Retry-After"))
+		}
+	}
+}
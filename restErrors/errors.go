@@ -0,0 +1,73 @@
+package restErrors
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/logging"
+	"server/restTypes"
+	"server/validation"
+	"strings"
+)
+
+// Write sends an error response with the given HTTP status and message.
+func Write(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteFields(w, r, status, message, nil)
+}
+
+// WriteFields sends an error response that also lists the problems with
+// individual input fields.
+func WriteFields(w http.ResponseWriter, r *http.Request, status int, message string, fields []restTypes.FieldError) {
+	WriteResponse(w, r, restTypes.ErrorResponse{Code: status, Message: message, Fields: fields})
+}
+
+// Invalid sends a 400 response listing the problems validation found with
+// the input.
+func Invalid(w http.ResponseWriter, r *http.Request, errs validation.Errors) {
+	fields := make([]restTypes.FieldError, len(errs))
+	for i, err := range errs {
+		fields[i] = restTypes.FieldError{Field: err.Field, Message: err.Message}
+	}
+	WriteFields(w, r, http.StatusBadRequest, "The input is invalid", fields)
+}
+
+// WriteResponse sends resp with its Code as the HTTP status, filling in the
+// error kind and the request ID.
+func WriteResponse(w http.ResponseWriter, r *http.Request, resp restTypes.ErrorResponse) {
+	if resp.Code < 400 {
+		resp.Code = http.StatusInternalServerError
+	}
+	if resp.Message == "" {
+		resp.Message = http.StatusText(resp.Code)
+	}
+	resp.Error = Kind(resp.Code)
+	resp.RequestID = logging.RequestID(r.Context())
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Internal logs err under message and sends a 500 response that does not
+// reveal it to the client.
+func Internal(w http.ResponseWriter, r *http.Request, message string, err error) {
+	logging.FromContext(r.Context()).Error(message, "error", err)
+	Write(w, r, http.StatusInternalServerError, "Internal server error")
+}
+
+// Handler returns a handler that always sends the given error, for use as a
+// router's NotFound or MethodNotAllowed handler.
+func Handler(status int, message string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, status, message)
+	})
+}
+
+// Kind names the error kind of an HTTP status, such as "not_found" for 404.
+func Kind(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
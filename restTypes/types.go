@@ -1,7 +1,10 @@
 package restTypes
 
 import (
+	"fmt"
 	"server/databaseTypes"
+	"server/validation"
+	"strings"
 	"time"
 )
 
@@ -11,14 +14,23 @@ type GetEventsResponse struct {
 
 // Event represents the data structure for the Event table
 type Event struct {
-	ID          string    `json:"id" example:"1"`                           // Unique identifier for the schedule
-	Title       string    `json:"title" example:"New event"`                // Title of the event
-	Description string    `json:"description" example:"asdasd"`             // Description of the event
-	Start       time.Time `json:"start" example:"2023-08-07T04:30:00.000Z"` // Start date and time of the event (in ISO 8601 format)
-	End         time.Time `json:"end" example:"2023-08-07T07:00:00.000Z"`   // End date and time of the event (in ISO 8601 format)
-	Status      string    `json:"status" example:"busy"`                    // Status of the event (e.g., "busy" or "free")
-	Color       string    `json:"color" example:"rgba(220,114,114,0.6)"`    // Color representation of the event
-	Location    string    `json:"location" example:"sadsadad"`              // Location of the event
+	ID          string    `json:"id" example:"1" validate:"required,max=100"`                   // Unique identifier for the schedule
+	Title       string    `json:"title" example:"New event" validate:"required,max=200"`        // Title of the event
+	Description string    `json:"description" example:"asdasd" validate:"max=2000"`             // Description of the event
+	Start       time.Time `json:"start" example:"2023-08-07T04:30:00.000Z" validate:"required"` // Start date and time of the event (in ISO 8601 format)
+	End         time.Time `json:"end" example:"2023-08-07T07:00:00.000Z" validate:"required"`   // End date and time of the event (in ISO 8601 format)
+	Status      string    `json:"status" example:"busy" validate:"max=50"`                      // Status of the event (e.g., "busy" or "free")
+	Color       string    `json:"color" example:"rgba(220,114,114,0.6)" validate:"max=50"`      // Color representation of the event
+	Location    string    `json:"location" example:"sadsadad" validate:"max=200"`               // Location of the event
+}
+
+// Validate checks that the event does not end before it starts.
+func (e Event) Validate() validation.Errors {
+	var errs validation.Errors
+	if e.End.Before(e.Start) {
+		errs.Add("end", "must not be before start")
+	}
+	return errs
 }
 
 // LoginRequest represents the request body for the login API.
@@ -27,14 +39,27 @@ type LoginRequest struct {
 	//
 	//
 	// Required: true
-	Username string `json:"username" example:"johnsmith@example.com"`
+	Username string `json:"username" example:"johnsmith@example.com" validate:"required,max=254"`
 
 	// User's password.
 	//
 	// Example: mypassword123
 	//
 	// Required: true
-	Password string `json:"password" example:"password1"`
+	Password string `json:"password" example:"password1" validate:"required,max=72"`
+
+	// Name of the device logging in, shown in the list of sessions.
+	//
+	// Required: false
+	DeviceName string `json:"device_name,omitempty" example:"Pixel 8" validate:"max=100"`
+}
+
+// RefreshRequest represents the request body for the refresh API.
+type RefreshRequest struct {
+	// Refresh token from the last login or refresh.
+	//
+	// Required: true
+	RefreshToken string `json:"refresh_token" example:"3q2-7wVp0tT9lq5yQX3v2fHk1J8cYl4mN6bR0sDxA1E" validate:"required,max=100"`
 }
 
 // LoginResponse represents the response object returned by the login API.
@@ -53,13 +78,26 @@ type LoginResponse struct {
 	// Required: true
 	Message string `json:"message" example:"Login successful"`
 
-	// JWT token to be used for authentication in future requests.
+	// JWT access token to be used for authentication in future requests.
 	//
 	// Example: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
 	//
 	// Required: true
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"`
 
+	// Seconds until the access token expires.
+	//
+	// Example: 900
+	//
+	// Required: true
+	ExpiresIn int `json:"expires_in" example:"900"`
+
+	// Token to exchange for new tokens at /auth/refresh once the access
+	// token expires. It can be used only once.
+	//
+	// Required: true
+	RefreshToken string `json:"refresh_token" example:"3q2-7wVp0tT9lq5yQX3v2fHk1J8cYl4mN6bR0sDxA1E"`
+
 	// User data associated with the logged-in user.
 	//
 	// Required: false
@@ -71,21 +109,51 @@ type LoginResponse struct {
 	UserData *databaseTypes.User `json:"user_data,omitempty"`
 }
 
-// ErrorResponse represents an error response.
+// SessionsResponse lists the sessions of the user.
+type SessionsResponse struct {
+	Items []databaseTypes.Session `json:"items"`
+}
+
+// ErrorResponse represents an error response. Every endpoint reports errors
+// in this shape, with the HTTP status of the response in Code.
 type ErrorResponse struct {
 	// HTTP status code of the error response.
 	//
 	// Example: 400
 	//
 	// Required: true
-	Code int `json:"code"`
+	Code int `json:"code" example:"400"`
+
+	// Error names the kind of error, derived from the status code.
+	//
+	// Example: bad_request
+	Error string `json:"error,omitempty" example:"bad_request"`
 
 	// Error message.
 	//
 	// Example: Invalid request
 	//
 	// Required: true
-	Message string `json:"message"`
+	Message string `json:"message" example:"Invalid request"`
+
+	// Fields lists the problems with individual input fields, if any.
+	Fields []FieldError `json:"fields,omitempty"`
+
+	// RequestID identifies the request in the server logs.
+	RequestID string `json:"request_id,omitempty" example:"3f2a9c1e5b7d4e60"`
+}
+
+// FieldError describes what is wrong with one input field.
+type FieldError struct {
+	Field   string `json:"field" example:"date"`
+	Message string `json:"message" example:"must be a date in the form YYYY-MM-DD"`
+}
+
+// StatusResponse is the response of a write that returns nothing but the
+// outcome.
+type StatusResponse struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Food menu added successfully"`
 }
 
 type DeleteResponse struct {
@@ -93,12 +161,60 @@ type DeleteResponse struct {
 	Message string `json:"message"`
 }
 
+// Page describes which part of a list a response holds. List endpoints take
+// limit, cursor and sort query parameters; pass next_cursor as the cursor,
+// or follow next, to get the following page.
+type Page struct {
+	// Number of items matching the filters, on every page.
+	Total int `json:"total" example:"120"`
+	// Most items in one page, 0 when the page holds the rest of the list.
+	Limit int `json:"limit" example:"50"`
+	// Cursor of the next page, absent on the last page.
+	NextCursor string `json:"next_cursor,omitempty" example:"bzo1MA"`
+	// Path and query of the next page, absent on the last page.
+	Next string `json:"next,omitempty" example:"/data/lost-and-found/?cursor=bzo1MA"`
+}
+
 type AllMenuResponse struct {
 	Items []databaseTypes.FoodMenu `json:"items"`
+	Page
+}
+
+// Menu is the food menu of one day with each meal a list of dishes, as
+// served by version 2 of the API.
+type Menu struct {
+	ID        int                  `json:"id" example:"1"`
+	Date      string               `json:"date" example:"2022-01-01" validate:"required,date"`
+	Breakfast []databaseTypes.Dish `json:"breakfast"`
+	Lunch     []databaseTypes.Dish `json:"lunch"`
+	Dinner    []databaseTypes.Dish `json:"dinner"`
+}
+
+// Validate checks that every dish has a name.
+func (m Menu) Validate() validation.Errors {
+	var errs validation.Errors
+	for _, meal := range []struct {
+		name   string
+		dishes []databaseTypes.Dish
+	}{{"breakfast", m.Breakfast}, {"lunch", m.Lunch}, {"dinner", m.Dinner}} {
+		for i, dish := range meal.dishes {
+			if strings.TrimSpace(dish.Name) == "" {
+				errs.Add(fmt.Sprintf("%s[%d].name", meal.name, i), "is required")
+			}
+		}
+	}
+	return errs
+}
+
+// MenuList is a page of menus in version 2 of the API.
+type MenuList struct {
+	Items []Menu `json:"items"`
+	Page
 }
 
 type LostAndFoundResponse struct {
 	Items []databaseTypes.LostAndFound `json:"items"`
+	Page
 }
 
 type LostAndFoundPostResponse struct {
@@ -107,32 +223,38 @@ type LostAndFoundPostResponse struct {
 	ID      int64  `json:"id,omitempty"`
 }
 
-type LostAndFoundErrorResponse struct {
-	Error string `json:"error"`
-}
-
 type LostAndFoundInput struct {
-	ItemName      string `json:"item_name"`
-	Description   string `json:"description"`
-	DateFound     string `json:"date_found"`
-	LocationFound string `json:"location_found"`
-	Status        string `json:"status"`
+	ItemName    string `json:"item_name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=2000"`
+	// A YYYY-MM-DD date or an RFC 3339 timestamp.
+	DateFound     string `json:"date_found" validate:"required,datetime"`
+	LocationFound string `json:"location_found" validate:"required,max=200"`
+	// databaseTypes.LostAndFoundLost or LostAndFoundFound.
+	Status string `json:"status" validate:"required,oneof=0 1"`
 }
 
 type SportsDataList struct {
 	List []databaseTypes.SportsInfo `json:"list"`
+	Page
 }
 
 type SportsGameDataList struct {
 	List []databaseTypes.SportsGame `json:"list"`
+	Page
 }
 
 type SchoolStoreResponse struct {
 	List []databaseTypes.SchoolStore `json:"list"`
+	Page
 }
 
-type SchoolStoreErrorResponse struct {
-	Error string `json:"error"`
+// SchoolStoreInput holds the form fields of a school store item.
+type SchoolStoreInput struct {
+	ItemName    string  `json:"item_name" validate:"required,max=100"`
+	Description string  `json:"description" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"min=0,max=100000"`
+	Category    int     `json:"category" validate:"min=0"`
+	Stock       int     `json:"stock" validate:"min=0"`
 }
 
 type SchoolStorePostResponse struct {
@@ -140,3 +262,74 @@ type SchoolStorePostResponse struct {
 	Message string `json:"message"`
 	ID      int64  `json:"id"`
 }
+
+type BackupResponse struct {
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	File      string `json:"file"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+type AuditLogResponse struct {
+	Items []databaseTypes.AuditEntry `json:"items"`
+	Page
+}
+
+type TrashResponse struct {
+	Items []databaseTypes.TrashItem `json:"items"`
+	Page
+}
+
+// RestoreResponse reports an item taken out of the trash.
+type RestoreResponse struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Item restored"`
+	// Item is the restored item as it was listed in the trash.
+	Item databaseTypes.TrashItem `json:"item"`
+}
+
+// ImportPeopleReport describes what importing the People directory changed.
+type ImportPeopleReport struct {
+	DryRun bool `json:"dry_run"`
+	// Accounts created, with the initial password to hand out.
+	Added []ImportedPerson `json:"added"`
+	// Emails of accounts whose name was changed.
+	Updated []string `json:"updated"`
+	// Emails of accounts no longer listed in the directory. They are not deleted.
+	Departed []string `json:"departed"`
+	// Entries that could not be imported, with the reason.
+	Skipped   []string `json:"skipped"`
+	Unchanged int      `json:"unchanged"`
+}
+
+type ImportedPerson struct {
+	Email           string `json:"email"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	UserType        int    `json:"user_type"`
+	InitialPassword string `json:"initial_password"`
+}
+
+// MenuImportReport describes what a bulk food menu import did with each day.
+type MenuImportReport struct {
+	DryRun   bool            `json:"dry_run"`
+	Inserted int             `json:"inserted"`
+	Updated  int             `json:"updated"`
+	Skipped  int             `json:"skipped"`
+	Days     []MenuImportDay `json:"days"`
+	// Problems that stopped the import; nothing is written when there are any.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type MenuImportDay struct {
+	Date string `json:"date" example:"2023-05-22"`
+	// inserted, updated or skipped
+	Result string `json:"result" example:"inserted"`
+	Reason string `json:"reason,omitempty" example:"unchanged"`
+}
+
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"`
+	// Result of each readiness check, "ok" when it passed.
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,350 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Middleware wraps the handler of a route, e.g. to require authentication.
+type Middleware func(http.Handler) http.Handler
+
+// RouteMiddleware wraps the handler of every route and is told the pattern
+// the route was declared with, e.g. to label metrics by route.
+type RouteMiddleware func(pattern string, next http.Handler) http.Handler
+
+// Router dispatches requests to the routes declared with Handle by method and
+// path pattern. A pattern is a path whose segments are either literal,
+// a parameter such as {date} matching one segment, or a final {name...}
+// matching the rest of the path. Trailing slashes are ignored, so
+// "/data/sports/" and "/data/sports" are the same route.
+//
+// When several patterns match a path the most specific one wins: a literal
+// segment beats a parameter, which beats a rest-of-path parameter. If that
+// pattern has no route for the request's method the router answers 405 with
+// an Allow header, unless the pattern ends in a rest-of-path parameter: such
+// a pattern, like the "/{path...}" of the front end, only catches whatever
+// else is asked for, so other methods get a 404 instead.
+type Router struct {
+	// NotFound handles requests no pattern matches. It defaults to http.NotFound.
+	NotFound http.Handler
+	// MethodNotAllowed handles requests whose pattern has no route for their
+	// method, after the Allow header is set. It defaults to a plain 405.
+	MethodNotAllowed http.Handler
+
+	routeMiddleware []RouteMiddleware
+	patterns        []*pattern
+	byPattern       map[string]*pattern
+}
+
+// pattern holds the routes declared for one path pattern, by method.
+type pattern struct {
+	text     string
+	segments []segment
+	methods  map[string]http.Handler
+}
+
+type segmentKind int
+
+// The kinds are ordered by how specifically they match.
+const (
+	restSegment segmentKind = iota
+	paramSegment
+	literalSegment
+)
+
+type segment struct {
+	kind segmentKind
+	// value is the literal text or the parameter name.
+	value string
+}
+
+// New returns an empty Router whose routes are each wrapped in routeMiddleware.
+func New(routeMiddleware ...RouteMiddleware) *Router {
+	return &Router{routeMiddleware: routeMiddleware, byPattern: map[string]*pattern{}}
+}
+
+// Handle declares that requests with the given method and a path matching
+// pattern are served by handler, wrapped in middleware with the first one
+// outermost. It panics if the pattern is malformed or the route is declared
+// twice.
+func (rt *Router) Handle(method, path string, handler http.Handler, middleware ...Middleware) {
+	segments, err := parsePattern(path)
+	if err != nil {
+		panic(err)
+	}
+	key := patternKey(segments)
+	p, ok := rt.byPattern[key]
+	if !ok {
+		p = &pattern{text: path, segments: segments, methods: map[string]http.Handler{}}
+		rt.byPattern[key] = p
+		rt.patterns = append(rt.patterns, p)
+	}
+	if _, exists := p.methods[method]; exists {
+		panic(fmt.Sprintf("router: %s %s declared twice", method, path))
+	}
+	if p.text != path && !sameParams(p.segments, segments) {
+		panic(fmt.Sprintf("router: %s %s names its parameters differently from %s", method, path, p.text))
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	for i := len(rt.routeMiddleware) - 1; i >= 0; i-- {
+		handler = rt.routeMiddleware[i](p.text, handler)
+	}
+	p.methods[method] = handler
+}
+
+// HandleFunc is Handle for a handler function.
+func (rt *Router) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle(method, path, handler, middleware...)
+}
+
+// Group declares routes under a common path prefix, such as "/v1", each
+// wrapped in the middleware of the group before its own.
+type Group struct {
+	rt         *Router
+	prefix     string
+	middleware []Middleware
+}
+
+// Group returns a Group declaring routes on rt under prefix, which may be
+// empty to share only the middleware.
+func (rt *Router) Group(prefix string, middleware ...Middleware) *Group {
+	return &Group{rt: rt, prefix: strings.TrimSuffix(prefix, "/"), middleware: middleware}
+}
+
+// Handle declares a route at the group's prefix followed by path, as
+// Router.Handle does.
+func (g *Group) Handle(method, path string, handler http.Handler, middleware ...Middleware) {
+	all := make([]Middleware, 0, len(g.middleware)+len(middleware))
+	all = append(append(all, g.middleware...), middleware...)
+	g.rt.Handle(method, g.prefix+path, handler, all...)
+}
+
+// HandleFunc is Handle for a handler function.
+func (g *Group) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) {
+	g.Handle(method, path, handler, middleware...)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p, params := rt.match(r.URL.Path)
+	var handler http.Handler
+	var ok bool
+	if p != nil {
+		handler, ok = p.methods[r.Method]
+		if !ok && r.Method == http.MethodHead {
+			handler, ok = p.methods[http.MethodGet]
+		}
+		// A catch-all does not make every other method of the path exist
+		if !ok && p.catchAll() && r.Method != http.MethodOptions {
+			p = nil
+		}
+	}
+	if p == nil {
+		if rt.NotFound != nil {
+			rt.NotFound.ServeHTTP(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+		return
+	}
+	if !ok {
+		w.Header().Set("Allow", strings.Join(p.allowed(), ", "))
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if rt.MethodNotAllowed != nil {
+			rt.MethodNotAllowed.ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), contextKey{}, &routeInfo{pattern: p.text, params: params})
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Match returns the pattern of the route serving path, whatever the method,
+// or "" if no pattern matches it.
+func (rt *Router) Match(path string) string {
+	if p, _ := rt.match(path); p != nil {
+		return p.text
+	}
+	return ""
+}
+
+// match finds the most specific pattern matching path and the values of its parameters.
+func (rt *Router) match(path string) (*pattern, map[string]string) {
+	parts := splitPath(path)
+	var best *pattern
+	var bestParams map[string]string
+	for _, p := range rt.patterns {
+		params, ok := p.match(parts)
+		if ok && (best == nil || moreSpecific(p, best)) {
+			best, bestParams = p, params
+		}
+	}
+	return best, bestParams
+}
+
+// catchAll reports whether the pattern ends in a rest-of-path parameter.
+func (p *pattern) catchAll() bool {
+	return len(p.segments) > 0 && p.segments[len(p.segments)-1].kind == restSegment
+}
+
+func (p *pattern) match(parts []string) (map[string]string, bool) {
+	params := map[string]string{}
+	for i, seg := range p.segments {
+		if seg.kind == restSegment {
+			params[seg.value] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		switch seg.kind {
+		case literalSegment:
+			if parts[i] != seg.value {
+				return nil, false
+			}
+		case paramSegment:
+			params[seg.value] = parts[i]
+		}
+	}
+	return params, len(parts) == len(p.segments)
+}
+
+// allowed lists the methods the pattern serves, as sent in the Allow header.
+func (p *pattern) allowed() []string {
+	methods := []string{http.MethodOptions}
+	for method := range p.methods {
+		methods = append(methods, method)
+	}
+	if _, ok := p.methods[http.MethodGet]; ok {
+		if _, ok := p.methods[http.MethodHead]; !ok {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// moreSpecific reports whether a matches more specifically than b, comparing
+// their segments from the left.
+func moreSpecific(a, b *pattern) bool {
+	for i := 0; i < len(a.segments) && i < len(b.segments); i++ {
+		if a.segments[i].kind != b.segments[i].kind {
+			return a.segments[i].kind > b.segments[i].kind
+		}
+	}
+	return len(a.segments) > len(b.segments)
+}
+
+func parsePattern(path string) ([]segment, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("router: pattern %q must start with /", path)
+	}
+	parts := splitPath(path)
+	segments := make([]segment, len(parts))
+	seen := map[string]bool{}
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("router: malformed segment %q in pattern %q", part, path)
+			}
+			segments[i] = segment{kind: literalSegment, value: part}
+			continue
+		}
+		name := part[1 : len(part)-1]
+		kind := paramSegment
+		if strings.HasSuffix(name, "...") {
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("router: %s must be the last segment of pattern %q", part, path)
+			}
+			name = strings.TrimSuffix(name, "...")
+			kind = restSegment
+		}
+		if name == "" || seen[name] {
+			return nil, fmt.Errorf("router: bad or repeated parameter %q in pattern %q", part, path)
+		}
+		seen[name] = true
+		segments[i] = segment{kind: kind, value: name}
+	}
+	return segments, nil
+}
+
+// patternKey identifies a pattern regardless of its parameter names and
+// trailing slash, so "/a/{id}" and "/a/{id}/" are the same pattern.
+func patternKey(segments []segment) string {
+	parts := make([]string, len(segments))
+	for i, seg := range segments {
+		switch seg.kind {
+		case literalSegment:
+			parts[i] = seg.value
+		case paramSegment:
+			parts[i] = "{}"
+		case restSegment:
+			parts[i] = "{...}"
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+func sameParams(a, b []segment) bool {
+	for i := range a {
+		if a[i].value != b[i].value {
+			return false
+		}
+	}
+	return true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+type contextKey struct{}
+
+type routeInfo struct {
+	pattern string
+	params  map[string]string
+}
+
+func info(r *http.Request) *routeInfo {
+	if info, ok := r.Context().Value(contextKey{}).(*routeInfo); ok {
+		return info
+	}
+	return &routeInfo{}
+}
+
+// Pattern returns the pattern of the route serving r, or "" outside a route.
+func Pattern(r *http.Request) string {
+	return info(r).pattern
+}
+
+// Param returns the value of the named path parameter of the route serving r,
+// or "" if the route has no such parameter.
+func Param(r *http.Request, name string) string {
+	return info(r).params[name]
+}
+
+// IntParam returns the named path parameter of the route serving r as an int.
+func IntParam(r *http.Request, name string) (int, error) {
+	value := Param(r, name)
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("path parameter %s must be an integer, got %q", name, value)
+	}
+	return n, nil
+}
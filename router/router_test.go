@@ -0,0 +1,181 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+func TestMatch(t *testing.T) {
+	rt := New()
+	for _, path := range []string{
+		"/data/food-menu/",
+		"/data/food-menu/all",
+		"/data/food-menu/{date}",
+		"/v1/data/food-menu/{date}",
+		"/admin/trash/{entity_type}/{id}/restore",
+		"/static/{path...}",
+		"/{path...}",
+	} {
+		rt.HandleFunc(http.MethodGet, path, ok)
+	}
+
+	tests := []struct {
+		path    string
+		pattern string
+		params  map[string]string
+	}{
+		{"/data/food-menu", "/data/food-menu/", map[string]string{}},
+		{"/data/food-menu/", "/data/food-menu/", map[string]string{}},
+		{"/data/food-menu/all", "/data/food-menu/all", map[string]string{}},
+		{"/data/food-menu/2024-01-01", "/data/food-menu/{date}", map[string]string{"date": "2024-01-01"}},
+		{"/data/food-menu/2024-01-01/", "/data/food-menu/{date}", map[string]string{"date": "2024-01-01"}},
+		{"/v1/data/food-menu/2024-01-01", "/v1/data/food-menu/{date}", map[string]string{"date": "2024-01-01"}},
+		{"/admin/trash/event/7/restore", "/admin/trash/{entity_type}/{id}/restore", map[string]string{"entity_type": "event", "id": "7"}},
+		{"/static/js/main.js", "/static/{path...}", map[string]string{"path": "js/main.js"}},
+		{"/data/food-menu/2024-01-01/extra", "/{path...}", map[string]string{"path": "data/food-menu/2024-01-01/extra"}},
+		{"/", "/{path...}", map[string]string{"path": ""}},
+	}
+	for _, tt := range tests {
+		p, params := rt.match(tt.path)
+		if p == nil {
+			t.Errorf("match(%q) found no pattern, want %q", tt.path, tt.pattern)
+			continue
+		}
+		if p.text != tt.pattern {
+			t.Errorf("match(%q) = %q, want %q", tt.path, p.text, tt.pattern)
+		}
+		if !reflect.DeepEqual(params, tt.params) {
+			t.Errorf("match(%q) params = %v, want %v", tt.path, params, tt.params)
+		}
+	}
+}
+
+func TestMatchNone(t *testing.T) {
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/data/food-menu/{date}", ok)
+	for _, path := range []string{"/", "/data", "/data/food-menu/a/b", "/data/sports/"} {
+		if p, _ := rt.match(path); p != nil {
+			t.Errorf("match(%q) = %q, want none", path, p.text)
+		}
+	}
+}
+
+func TestMoreSpecific(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/data/food-menu/all", "/data/food-menu/{date}", true},
+		{"/data/food-menu/{date}", "/data/food-menu/all", false},
+		{"/data/food-menu/{date}", "/data/{path...}", true},
+		{"/data/{path...}", "/data/food-menu/{date}", false},
+		{"/static/{path...}", "/{path...}", true},
+		{"/{path...}", "/static/{path...}", false},
+		{"/a/{id}/b", "/a/{id}/{name}", true},
+		{"/a/{id}/{name}", "/a/{id}", true},
+		{"/a/{id}", "/a/{id}", false},
+	}
+	for _, tt := range tests {
+		a := mustPattern(t, tt.a)
+		b := mustPattern(t, tt.b)
+		if got := moreSpecific(a, b); got != tt.want {
+			t.Errorf("moreSpecific(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func mustPattern(t *testing.T, path string) *pattern {
+	t.Helper()
+	segments, err := parsePattern(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pattern{text: path, segments: segments}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	for _, path := range []string{
+		"data",
+		"/a/{path...}/b",
+		"/a/{}",
+		"/a/{id}/{id}",
+		"/a/b{id}",
+	} {
+		if _, err := parsePattern(path); err == nil {
+			t.Errorf("parsePattern(%q) succeeded, want an error", path)
+		}
+	}
+}
+
+func TestServeHTTPStatus(t *testing.T) {
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/data/food-menu/{date}", ok)
+	rt.HandleFunc(http.MethodPut, "/data/food-menu/{date}", ok)
+	rt.HandleFunc(http.MethodGet, "/{path...}", ok)
+
+	tests := []struct {
+		method, path string
+		status       int
+		allow        string
+	}{
+		{http.MethodGet, "/data/food-menu/2024-01-01", http.StatusOK, ""},
+		{http.MethodHead, "/data/food-menu/2024-01-01", http.StatusOK, ""},
+		{http.MethodPut, "/data/food-menu/2024-01-01", http.StatusOK, ""},
+		{http.MethodDelete, "/data/food-menu/2024-01-01", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS, PUT"},
+		{http.MethodOptions, "/data/food-menu/2024-01-01", http.StatusNoContent, "GET, HEAD, OPTIONS, PUT"},
+		// The catch-all serves any path by GET but makes no other method exist
+		{http.MethodGet, "/login", http.StatusOK, ""},
+		{http.MethodPost, "/login", http.StatusNotFound, ""},
+		{http.MethodDelete, "/data/food-menu/2024-01-01/extra", http.StatusNotFound, ""},
+		{http.MethodOptions, "/login", http.StatusNoContent, "GET, HEAD, OPTIONS"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, w.Code, tt.status)
+		}
+		if allow := w.Header().Get("Allow"); allow != tt.allow {
+			t.Errorf("%s %s Allow = %q, want %q", tt.method, tt.path, allow, tt.allow)
+		}
+	}
+}
+
+func TestHandleTwicePanics(t *testing.T) {
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/data/sports/", ok)
+	defer func() {
+		if recover() == nil {
+			t.Error("declaring GET /data/sports twice did not panic")
+		}
+	}()
+	rt.HandleFunc(http.MethodGet, "/data/sports", ok)
+}
+
+func TestParams(t *testing.T) {
+	rt := New()
+	var id int
+	var err error
+	var pattern string
+	rt.HandleFunc(http.MethodGet, "/data/lost-and-found/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err = IntParam(r, "id")
+		pattern = Pattern(r)
+	})
+
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/data/lost-and-found/42", nil))
+	if err != nil || id != 42 {
+		t.Errorf("IntParam = %d, %v, want 42", id, err)
+	}
+	if pattern != "/data/lost-and-found/{id}" {
+		t.Errorf("Pattern = %q", pattern)
+	}
+
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/data/lost-and-found/abc", nil))
+	if err == nil {
+		t.Error("IntParam of abc succeeded")
+	}
+}
@@ -0,0 +1,21 @@
+#!/bin/sh
+# check-docs.sh fails if docs/ is not what swag generates from the current
+# annotations, i.e. someone changed a handler's annotations without running
+# "swag init" or edited the generated files by hand. Run it before pushing.
+#
+# SWAG is the swag command to run; by default the version in go.mod is
+# fetched and run with "go run".
+set -eu
+
+cd "$(dirname "$0")/.."
+SWAG=${SWAG:-go run github.com/swaggo/swag/cmd/swag@v1.8.12}
+
+out=$(mktemp -d)
+trap 'rm -rf "$out"' EXIT
+
+# The package of docs.go is named after the output directory
+$SWAG init --quiet --output "$out/docs"
+if ! diff -ru docs "$out/docs"; then
+	echo "docs/ is out of date; run \"swag init\" and commit the result" >&2
+	exit 1
+fi
@@ -0,0 +1,157 @@
+package tlsService
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"server/config"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// versions maps the values of tls.min_version to TLS versions.
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Service serves the configured certificate, reloading it when its files change.
+type Service struct {
+	cfg config.TLS
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+// New loads the certificate and key named by cfg.
+func New(cfg config.TLS) (*Service, error) {
+	s := &Service{cfg: cfg}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Config returns the TLS settings for the HTTPS server.
+func (s *Service) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion:     versions[s.cfg.MinVersion],
+		GetCertificate: s.getCertificate,
+	}
+}
+
+func (s *Service) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert, nil
+}
+
+// reload reads the certificate and key again if either file changed since
+// they were last read, and reports whether it did. A pair that fails to
+// load leaves the current certificate in use.
+func (s *Service) reload() (bool, error) {
+	modified, err := lastModified(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	unchanged := s.cert != nil && modified.Equal(s.modified)
+	s.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return false, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	s.mu.Lock()
+	s.cert, s.modified = &cert, modified
+	s.mu.Unlock()
+	return true, nil
+}
+
+// lastModified returns the latest modification time of the files.
+func lastModified(paths ...string) (time.Time, error) {
+	var latest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// Start checks the certificate and key files every configured interval
+// until stop is closed, and serves the new pair once they change, so a
+// renewed certificate is picked up without a restart.
+func (s *Service) Start(stop <-chan struct{}) {
+	interval := time.Duration(s.cfg.ReloadInterval)
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reloaded, err := s.reload()
+				if err != nil {
+					slog.Error("TLS certificate reload failed", "error", err)
+					continue
+				}
+				if reloaded {
+					slog.Info("TLS certificate reloaded", "cert_file", s.cfg.CertFile)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// HSTS tells browsers to only use HTTPS for the host for the configured
+// time, so later visits are never sent over plain HTTP.
+func (s *Service) HSTS(next http.Handler) http.Handler {
+	maxAge := int(time.Duration(s.cfg.HSTSMaxAge).Seconds())
+	if maxAge <= 0 {
+		return next
+	}
+	value := "max-age=" + strconv.Itoa(maxAge)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", value)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Redirect sends every request to the same URL over HTTPS on the port of
+// httpsAddr, the address the HTTPS server listens on.
+func Redirect(httpsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		target := "https://" + host + r.URL.RequestURI()
+		// 308 keeps the method and body of API calls, unlike 301
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
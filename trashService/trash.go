@@ -0,0 +1,52 @@
+package trashService
+
+import (
+	"log/slog"
+	"server/config"
+	"server/databaseControllers"
+	"time"
+)
+
+// Service purges the items that have been in the trash longer than the
+// retention period.
+type Service struct {
+	trash databaseControllers.TrashRepo
+	cfg   config.Trash
+}
+
+// New returns a Service purging trash as configured by cfg.
+func New(trash databaseControllers.TrashRepo, cfg config.Trash) *Service {
+	return &Service{trash: trash, cfg: cfg}
+}
+
+// Purge deletes for good the items deleted more than the retention period
+// ago and returns how many there were.
+func (s *Service) Purge() (int64, error) {
+	return s.trash.Purge(time.Now().Add(-time.Duration(s.cfg.Retention)))
+}
+
+// Start purges the trash once and then every configured interval until stop
+// is closed. It does nothing if the interval is zero.
+func (s *Service) Start(stop <-chan struct{}) {
+	interval := time.Duration(s.cfg.PurgeInterval)
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purged, err := s.Purge()
+			if err != nil {
+				slog.Error("trash purge failed", "error", err)
+			} else if purged > 0 {
+				slog.Info("trash purged", "items", purged)
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,225 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Error is a problem with one input field, named as in its JSON.
+type Error struct {
+	Field   string
+	Message string
+}
+
+// Errors lists the problems found with an input, in field order.
+type Errors []Error
+
+// Add appends a problem with field.
+func (e *Errors) Add(field, message string) {
+	*e = append(*e, Error{Field: field, Message: message})
+}
+
+// Has reports whether there is a problem with field.
+func (e Errors) Has(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Validator is implemented by inputs with checks their tags cannot express,
+// such as one field having to come after another. Validate is only called
+// once every tagged field is valid.
+type Validator interface {
+	Validate() Errors
+}
+
+// Struct checks the fields of the struct v points to against the rules in
+// their validate tags, then calls its Validate method if it has one. The
+// rules, separated by commas, are:
+//
+//	required   not empty, or for a time not zero
+//	min=N      a number of at least N, or a string of at least N characters
+//	max=N      a number of at most N, or a string of at most N characters
+//	date       a YYYY-MM-DD date
+//	datetime   a YYYY-MM-DD date or an RFC 3339 timestamp
+//	oneof=a b  one of the listed values
+//
+// Rules other than required accept an empty string, so optional fields are
+// only checked when given.
+func Struct(v interface{}) Errors {
+	errs := check(v, nil)
+	if len(errs) > 0 {
+		return errs
+	}
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// Only checks the named fields of the struct v points to against their tags,
+// for partial updates where the other fields are left as they are.
+func Only(v interface{}, fields ...string) Errors {
+	only := map[string]bool{}
+	for _, field := range fields {
+		only[field] = true
+	}
+	return check(v, only)
+}
+
+func check(v interface{}, only map[string]bool) Errors {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validation: %T is not a struct", v))
+	}
+
+	var errs Errors
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		name := fieldName(field)
+		if only != nil && !only[name] {
+			continue
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if message := apply(rule, value.Field(i)); message != "" {
+				errs.Add(name, message)
+				break
+			}
+		}
+	}
+	return errs
+}
+
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// apply checks value against one rule, returning what is wrong with it or "".
+func apply(rule string, value reflect.Value) string {
+	name, arg := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		if name == "required" && t.IsZero() {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		s := value.String()
+		if name == "required" {
+			if strings.TrimSpace(s) == "" {
+				return "is required"
+			}
+			return ""
+		}
+		if s == "" {
+			return ""
+		}
+		switch name {
+		case "min":
+			if utf8.RuneCountInString(s) < mustInt(rule, arg) {
+				return fmt.Sprintf("must be at least %s characters", arg)
+			}
+		case "max":
+			if utf8.RuneCountInString(s) > mustInt(rule, arg) {
+				return fmt.Sprintf("must be at most %s characters", arg)
+			}
+		case "date":
+			if _, err := time.Parse("2006-01-02", s); err != nil {
+				return "must be a date in the form YYYY-MM-DD"
+			}
+		case "datetime":
+			if _, err := time.Parse("2006-01-02", s); err == nil {
+				return ""
+			}
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return "must be a YYYY-MM-DD date or an RFC 3339 timestamp"
+			}
+		case "oneof":
+			if !oneOf(s, arg) {
+				return "must be one of " + strings.Join(strings.Fields(arg), ", ")
+			}
+		default:
+			panic("validation: unknown rule " + rule + " for a string")
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		var n float64
+		if value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64 {
+			n = value.Float()
+		} else {
+			n = float64(value.Int())
+		}
+		switch name {
+		case "required":
+			if n == 0 {
+				return "is required"
+			}
+		case "min":
+			if n < mustFloat(rule, arg) {
+				return "must be at least " + arg
+			}
+		case "max":
+			if n > mustFloat(rule, arg) {
+				return "must be at most " + arg
+			}
+		case "oneof":
+			if !oneOf(strconv.FormatFloat(n, 'f', -1, 64), arg) {
+				return "must be one of " + strings.Join(strings.Fields(arg), ", ")
+			}
+		default:
+			panic("validation: unknown rule " + rule + " for a number")
+		}
+
+	default:
+		panic(fmt.Sprintf("validation: cannot apply %s to a %s", rule, value.Type()))
+	}
+	return ""
+}
+
+func oneOf(s, list string) bool {
+	for _, allowed := range strings.Fields(list) {
+		if s == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func mustInt(rule, arg string) int {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		panic("validation: bad argument in rule " + rule)
+	}
+	return n
+}
+
+func mustFloat(rule, arg string) float64 {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		panic("validation: bad argument in rule " + rule)
+	}
+	return n
+}
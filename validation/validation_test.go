@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type input struct {
+	Name   string    `json:"name" validate:"required,max=5"`
+	Code   string    `json:"code,omitempty" validate:"min=2"`
+	Date   string    `json:"date" validate:"date"`
+	When   string    `json:"when" validate:"datetime"`
+	Kind   string    `json:"kind" validate:"oneof=a b"`
+	Price  float64   `json:"price" validate:"min=0,max=100"`
+	Status int       `json:"status" validate:"oneof=0 1 2"`
+	Start  time.Time `json:"start" validate:"required"`
+	Other  string
+}
+
+func valid() input {
+	return input{Name: "Pasta", Date: "2024-01-01", When: "2024-01-01T08:00:00Z", Kind: "a", Price: 3.5, Status: 1, Start: time.Now()}
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*input)
+		errs   Errors
+	}{
+		{"valid", func(*input) {}, nil},
+		{"optional fields empty", func(in *input) { in.Date, in.When, in.Kind = "", "", "" }, nil},
+		{"date as when", func(in *input) { in.When = "2024-01-01" }, nil},
+		{"name missing", func(in *input) { in.Name = "  " }, Errors{{Field: "name", Message: "is required"}}},
+		// Characters are counted, not bytes
+		{"name of 5 characters", func(in *input) { in.Name = "Crêpe" }, nil},
+		{"name too long", func(in *input) { in.Name = "Lasagne" }, Errors{{Field: "name", Message: "must be at most 5 characters"}}},
+		{"code too short", func(in *input) { in.Code = "x" }, Errors{{Field: "code", Message: "must be at least 2 characters"}}},
+		{"bad date", func(in *input) { in.Date = "01/01/2024" }, Errors{{Field: "date", Message: "must be a date in the form YYYY-MM-DD"}}},
+		{"bad when", func(in *input) { in.When = "tomorrow" }, Errors{{Field: "when", Message: "must be a YYYY-MM-DD date or an RFC 3339 timestamp"}}},
+		{"bad kind", func(in *input) { in.Kind = "c" }, Errors{{Field: "kind", Message: "must be one of a, b"}}},
+		{"negative price", func(in *input) { in.Price = -1 }, Errors{{Field: "price", Message: "must be at least 0"}}},
+		{"bad status", func(in *input) { in.Status = 3 }, Errors{{Field: "status", Message: "must be one of 0, 1, 2"}}},
+		{"no start", func(in *input) { in.Start = time.Time{} }, Errors{{Field: "start", Message: "is required"}}},
+		{"several", func(in *input) { in.Name, in.Price = "", 101 }, Errors{{Field: "name", Message: "is required"}, {Field: "price", Message: "must be at most 100"}}},
+	}
+	for _, tt := range tests {
+		in := valid()
+		tt.change(&in)
+		if errs := Struct(&in); !reflect.DeepEqual(errs, tt.errs) {
+			t.Errorf("%s: Struct = %v, want %v", tt.name, errs, tt.errs)
+		}
+	}
+}
+
+func TestOnly(t *testing.T) {
+	in := input{Date: "01/01/2024", Kind: "c"}
+	errs := Only(&in, "kind")
+	if want := (Errors{{Field: "kind", Message: "must be one of a, b"}}); !reflect.DeepEqual(errs, want) {
+		t.Errorf("Only(kind) = %v, want %v", errs, want)
+	}
+	if !errs.Has("kind") || errs.Has("date") {
+		t.Errorf("Has does not match the errors %v", errs)
+	}
+}
+
+type period struct {
+	Start string `json:"start" validate:"required,date"`
+	End   string `json:"end" validate:"required,date"`
+}
+
+func (p period) Validate() Errors {
+	var errs Errors
+	if p.End < p.Start {
+		errs.Add("end", "must not be before start")
+	}
+	return errs
+}
+
+func TestValidator(t *testing.T) {
+	if errs := Struct(&period{Start: "2024-01-02", End: "2024-01-01"}); !errs.Has("end") {
+		t.Errorf("Validate was not called: %v", errs)
+	}
+	// Validate is only called once the tags pass
+	if errs := Struct(&period{Start: "2024-01-02"}); len(errs) != 1 || errs[0].Message != "is required" {
+		t.Errorf("Struct = %v, want only end required", errs)
+	}
+}